@@ -9,4 +9,5 @@ function compile_fuzzer {
 }
 
 compile_fuzzer github.com/BeyondTrust/go-client-library-passwordsafe/fuzzing/managed_accounts FuzzGetManagedAccount FuzzGetManagedAccount
-compile_fuzzer github.com/BeyondTrust/go-client-library-passwordsafe/fuzzing/secrets FuzzGetSecret FuzzGetSecret
\ No newline at end of file
+compile_fuzzer github.com/BeyondTrust/go-client-library-passwordsafe/fuzzing/secrets FuzzGetSecret FuzzGetSecret
+compile_fuzzer github.com/BeyondTrust/go-client-library-passwordsafe/fuzzing/logging FuzzRedactingLogger FuzzRedactingLogger
@@ -0,0 +1,197 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package access_policies implements functions to manage access policies and role assignments in Password Safe.
+package access_policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/user_groups"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+type AccessPolicyObj struct {
+	log               logging.Logger
+	authenticationObj authentication.AuthenticationObj
+}
+
+var endpointPath = "AccessPolicies"
+
+// NewAccessPolicyObj creates AccessPolicyObj.
+func NewAccessPolicyObj(authentication authentication.AuthenticationObj, logger logging.Logger) (*AccessPolicyObj, error) {
+	accessPolicyObj := &AccessPolicyObj{
+		log:               logger,
+		authenticationObj: authentication,
+	}
+	return accessPolicyObj, nil
+}
+
+// GetAccessPoliciesListFlow returns the access policies of Password Safe.
+func (accessPolicyObj *AccessPolicyObj) GetAccessPoliciesListFlow() ([]entities.AccessPolicyResponse, error) {
+
+	var accessPoliciesResponse []entities.AccessPolicyResponse
+
+	getAccessPoliciesUrl := accessPolicyObj.authenticationObj.ApiUrl.JoinPath(endpointPath).String()
+	err := utils.GetResource(
+		getAccessPoliciesUrl,
+		accessPolicyObj.authenticationObj.ApiVersion,
+		constants.GetAccessPoliciesList,
+		&accessPoliciesResponse,
+		&accessPolicyObj.authenticationObj.HttpClient,
+		accessPolicyObj.authenticationObj.ExponentialBackOff,
+		accessPolicyObj.log,
+	)
+	if err != nil {
+		return accessPoliciesResponse, err
+	}
+
+	if len(accessPoliciesResponse) == 0 {
+		return accessPoliciesResponse, fmt.Errorf("empty access policies list")
+	}
+
+	return accessPoliciesResponse, nil
+}
+
+// GetAccessPolicyByNameFlow returns the access policy named name, names are compared case-insensitively.
+func (accessPolicyObj *AccessPolicyObj) GetAccessPolicyByNameFlow(name string) (entities.AccessPolicyResponse, error) {
+	accessPolicies, err := accessPolicyObj.GetAccessPoliciesListFlow()
+	if err != nil {
+		return entities.AccessPolicyResponse{}, err
+	}
+
+	for _, accessPolicy := range accessPolicies {
+		if strings.EqualFold(accessPolicy.Name, name) {
+			return accessPolicy, nil
+		}
+	}
+	return entities.AccessPolicyResponse{}, utils.NewNotFoundError("access policy %v was not found", name)
+}
+
+// GetRolesListFlow returns the roles that can be assigned to user groups on Smart Rules.
+func (accessPolicyObj *AccessPolicyObj) GetRolesListFlow() ([]entities.RoleResponse, error) {
+
+	var rolesResponse []entities.RoleResponse
+
+	getRolesUrl := accessPolicyObj.authenticationObj.ApiUrl.JoinPath("Roles").String()
+	err := utils.GetResource(
+		getRolesUrl,
+		accessPolicyObj.authenticationObj.ApiVersion,
+		constants.GetRolesList,
+		&rolesResponse,
+		&accessPolicyObj.authenticationObj.HttpClient,
+		accessPolicyObj.authenticationObj.ExponentialBackOff,
+		accessPolicyObj.log,
+	)
+	if err != nil {
+		return rolesResponse, err
+	}
+
+	if len(rolesResponse) == 0 {
+		return rolesResponse, fmt.Errorf("empty roles list")
+	}
+
+	return rolesResponse, nil
+}
+
+// TestAccessPolicyFlow calls AccessPolicies/Test and returns the access policies granting the
+// current user access to the managed account of testDetails for the requested duration.
+func (accessPolicyObj *AccessPolicyObj) TestAccessPolicyFlow(testDetails entities.AccessPolicyTestDetails) ([]entities.AccessPolicyResponse, error) {
+
+	var accessPoliciesResponse []entities.AccessPolicyResponse
+
+	err := utils.ValidateData(testDetails)
+	if err != nil {
+		return accessPoliciesResponse, err
+	}
+
+	testAccessPolicyUrl := accessPolicyObj.authenticationObj.ApiUrl.JoinPath(endpointPath, "Test").String()
+	response, err := utils.SendResource(
+		testAccessPolicyUrl,
+		"POST",
+		accessPolicyObj.authenticationObj.ApiVersion,
+		constants.TestAccessPolicy,
+		testDetails,
+		&accessPolicyObj.authenticationObj.HttpClient,
+		accessPolicyObj.authenticationObj.ExponentialBackOff,
+		accessPolicyObj.log,
+	)
+	if err != nil {
+		return accessPoliciesResponse, err
+	}
+
+	err = json.Unmarshal(response, &accessPoliciesResponse)
+	if err != nil {
+		return accessPoliciesResponse, err
+	}
+
+	return accessPoliciesResponse, nil
+}
+
+// VerifyRequestAccessFlow returns an error unless an access policy lets the current user
+// request accessType (View, RDP, SSH or App) access to the managed account of testDetails.
+func (accessPolicyObj *AccessPolicyObj) VerifyRequestAccessFlow(testDetails entities.AccessPolicyTestDetails, accessType string) error {
+	accessPolicies, err := accessPolicyObj.TestAccessPolicyFlow(testDetails)
+	if err != nil {
+		return err
+	}
+
+	for _, accessPolicy := range accessPolicies {
+		for _, schedule := range accessPolicy.Schedule {
+			for _, scheduleAccessType := range schedule.AccessTypes {
+				if strings.EqualFold(scheduleAccessType.AccessType, accessType) {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("no access policy grants %v access to account %v of system %v", accessType, testDetails.AccountId, testDetails.SystemId)
+}
+
+// GrantAccessFlow assigns the roles named in grantDetails, with the access policy named
+// AccessPolicyName when set, to the user group on the Smart Rule. The roles of the group on the
+// Smart Rule are replaced.
+func (accessPolicyObj *AccessPolicyObj) GrantAccessFlow(grantDetails entities.AccessGrantDetails) error {
+	err := utils.ValidateData(grantDetails)
+	if err != nil {
+		return err
+	}
+
+	roles, err := accessPolicyObj.GetRolesListFlow()
+	if err != nil {
+		return err
+	}
+
+	rolesDetails := entities.SmartRuleRolesDetails{}
+	for _, roleName := range grantDetails.RoleNames {
+		roleId := 0
+		for _, role := range roles {
+			if strings.EqualFold(role.Name, roleName) {
+				roleId = role.RoleID
+				break
+			}
+		}
+		if roleId == 0 {
+			return utils.NewNotFoundError("role %v was not found", roleName)
+		}
+		rolesDetails.Roles = append(rolesDetails.Roles, entities.SmartRuleRole{RoleID: roleId})
+	}
+
+	if grantDetails.AccessPolicyName != "" {
+		accessPolicy, err := accessPolicyObj.GetAccessPolicyByNameFlow(grantDetails.AccessPolicyName)
+		if err != nil {
+			return err
+		}
+		rolesDetails.AccessPolicyID = accessPolicy.AccessPolicyID
+	}
+
+	userGroupObj, err := user_groups.NewUserGroupObj(accessPolicyObj.authenticationObj, accessPolicyObj.log)
+	if err != nil {
+		return err
+	}
+	return userGroupObj.SetSmartRuleRolesFlow(grantDetails.UserGroupID, grantDetails.SmartRuleID, rolesDetails)
+}
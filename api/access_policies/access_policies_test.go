@@ -0,0 +1,181 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package access_policies implements functions to manage access policies and role assignments in Password Safe
+// Unit tests for access_policies package.
+package access_policies
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	backoff "github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+)
+
+var authParams *authentication.AuthenticationParametersObj
+var zapLogger *logging.ZapLogger
+var apiVersion string = constants.ApiVersion31
+
+const accessPoliciesResponse = `[{"AccessPolicyID": 4, "Name": "Business Hours", "Schedule": [{"ScheduleID": 1, "AccessTypes": [{"AccessType": "View", "AutoApprove": true}]}]},
+	{"AccessPolicyID": 5, "Name": "Sessions", "Schedule": [{"ScheduleID": 2, "AccessTypes": [{"AccessType": "RDP", "IsSession": true}]}]}]`
+
+func InitializeGlobalConfig() {
+
+	logger, _ := zap.NewDevelopment()
+
+	zapLogger = logging.NewZapLogger(logger)
+
+	httpClientObj, _ := utils.GetHttpClient(5, false, "", "", zapLogger)
+
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.MaxElapsedTime = time.Second
+
+	authParams = &authentication.AuthenticationParametersObj{
+		HTTPClient:                 *httpClientObj,
+		BackoffDefinition:          backoffDefinition,
+		EndpointURL:                constants.FakeApiUrl,
+		APIVersion:                 apiVersion,
+		ClientID:                   constants.FakeClientId,
+		ClientSecret:               constants.FakeClientSecret,
+		ApiKey:                     "",
+		Logger:                     zapLogger,
+		RetryMaxElapsedTimeSeconds: 300,
+	}
+}
+
+func TestAccessPolicyFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	var testBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /AccessPolicies":
+			_, err := w.Write([]byte(accessPoliciesResponse))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		case "POST /AccessPolicies/Test":
+			body, _ := io.ReadAll(r.Body)
+			testBody = string(body)
+			_, err := w.Write([]byte(`[{"AccessPolicyID": 4, "Name": "Business Hours", "Schedule": [{"ScheduleID": 1, "AccessTypes": [{"AccessType": "View"}]}]}]`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	accessPolicyObj, _ := NewAccessPolicyObj(*authenticate, zapLogger)
+
+	accessPolicies, err := accessPolicyObj.GetAccessPoliciesListFlow()
+	if err != nil || len(accessPolicies) != 2 || accessPolicies[1].Schedule[0].AccessTypes[0].AccessType != "RDP" {
+		t.Errorf("Test case Failed %v, %v", accessPolicies, err)
+	}
+
+	accessPolicy, err := accessPolicyObj.GetAccessPolicyByNameFlow("business hours")
+	if err != nil || accessPolicy.AccessPolicyID != 4 {
+		t.Errorf("Test case Failed %v, %v", accessPolicy, err)
+	}
+	if _, err = accessPolicyObj.GetAccessPolicyByNameFlow("Weekends"); err == nil {
+		t.Error("Test case Failed, an unknown access policy must fail")
+	}
+
+	testDetails := entities.AccessPolicyTestDetails{SystemId: 1, AccountId: 10, DurationMinutes: 60}
+	accessPolicies, err = accessPolicyObj.TestAccessPolicyFlow(testDetails)
+	if err != nil || len(accessPolicies) != 1 {
+		t.Errorf("Test case Failed %v, %v", accessPolicies, err)
+	}
+	if testBody != `{"SystemId":1,"AccountId":10,"DurationMinutes":60}` {
+		t.Errorf("Test case Failed %v", testBody)
+	}
+
+	if err = accessPolicyObj.VerifyRequestAccessFlow(testDetails, "view"); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if err = accessPolicyObj.VerifyRequestAccessFlow(testDetails, "RDP"); err == nil {
+		t.Error("Test case Failed, RDP access is not granted")
+	}
+
+	// error case, the duration is required.
+	_, err = accessPolicyObj.TestAccessPolicyFlow(entities.AccessPolicyTestDetails{SystemId: 1, AccountId: 10})
+
+	expetedErrorMessage := "The field 'DurationMinutes' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+}
+
+func TestGrantAccessFlow(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	var rolesBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /AccessPolicies":
+			_, _ = w.Write([]byte(accessPoliciesResponse))
+		case "GET /Roles":
+			_, _ = w.Write([]byte(`[{"RoleID": 1, "Name": "Requestor"}, {"RoleID": 2, "Name": "Approver"}, {"RoleID": 3, "Name": "Auditor"}]`))
+		case "POST /UserGroups/12/SmartRules/3/Roles":
+			body, _ := io.ReadAll(r.Body)
+			rolesBody = string(body)
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	accessPolicyObj, _ := NewAccessPolicyObj(*authenticate, zapLogger)
+
+	err := accessPolicyObj.GrantAccessFlow(entities.AccessGrantDetails{
+		UserGroupID:      12,
+		SmartRuleID:      3,
+		RoleNames:        []string{"requestor", "Approver"},
+		AccessPolicyName: "Business Hours",
+	})
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if rolesBody != `{"Roles":[{"RoleID":1},{"RoleID":2}],"AccessPolicyID":4}` {
+		t.Errorf("Test case Failed %v", rolesBody)
+	}
+
+	// Unknown roles are not assigned.
+	rolesBody = ""
+	err = accessPolicyObj.GrantAccessFlow(entities.AccessGrantDetails{UserGroupID: 12, SmartRuleID: 3, RoleNames: []string{"Owner"}})
+	if err == nil || rolesBody != "" {
+		t.Errorf("Test case Failed %v, %v", err, rolesBody)
+	}
+
+	// error case, roles are required.
+	err = accessPolicyObj.GrantAccessFlow(entities.AccessGrantDetails{UserGroupID: 12, SmartRuleID: 3})
+
+	expetedErrorMessage := "The field 'RoleNames' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+}
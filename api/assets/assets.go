@@ -15,8 +15,6 @@ import (
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
-
-	backoff "github.com/cenkalti/backoff/v4"
 )
 
 type AssetObj struct {
@@ -117,10 +115,10 @@ func (assetObj *AssetObj) createAsset(parameter string, assetDetails entities.As
 		ApiVersion:  assetObj.authenticationObj.ApiVersion,
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = assetObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, assetObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = assetObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, assetObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.AssetResponse{}, technicalError
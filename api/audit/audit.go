@@ -0,0 +1,137 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package audit implements an audit trail of secret accesses.
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Operation performed on a secret.
+type Operation string
+
+const (
+	OperationRead     Operation = "read"
+	OperationDownload Operation = "download"
+	OperationCreate   Operation = "create"
+	OperationDelete   Operation = "delete"
+	OperationCheckout Operation = "checkout"
+	OperationCheckIn  Operation = "checkin"
+	OperationRotate   Operation = "rotate"
+)
+
+// Outcome of an operation.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event describes one access to a secret. It never carries the secret value.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Principal string    `json:"principal"`
+	Operation Operation `json:"operation"`
+	Path      string    `json:"path,omitempty"`
+	SecretID  string    `json:"secretId,omitempty"`
+	Outcome   Outcome   `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Sink receives audit events. Implementations must be safe for concurrent use.
+type Sink interface {
+	Record(event Event)
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(event Event)
+
+// Record calls f(event).
+func (f SinkFunc) Record(event Event) {
+	f(event)
+}
+
+// Trail records the events of a caller-supplied principal to a sink.
+// A nil Trail records nothing, so API objects can hold one unconditionally.
+type Trail struct {
+	sink      Sink
+	principal string
+	now       func() time.Time
+}
+
+// NewTrail creates a Trail recording events of principal to sink.
+func NewTrail(sink Sink, principal string) *Trail {
+	return &Trail{
+		sink:      sink,
+		principal: principal,
+		now:       time.Now,
+	}
+}
+
+// Record sends an event for operation on path/secretID to the sink, the outcome
+// is a failure when err is not nil.
+func (trail *Trail) Record(operation Operation, path string, secretID string, err error) {
+	if trail == nil || trail.sink == nil {
+		return
+	}
+
+	event := Event{
+		Timestamp: trail.now().UTC(),
+		Principal: trail.principal,
+		Operation: operation,
+		Path:      path,
+		SecretID:  secretID,
+		Outcome:   OutcomeSuccess,
+	}
+
+	if err != nil {
+		event.Outcome = OutcomeFailure
+		event.Error = err.Error()
+	}
+
+	trail.sink.Record(event)
+}
+
+// JSONLinesFileSink appends every event as one JSON document per line to a file.
+type JSONLinesFileSink struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	onError func(err error)
+}
+
+// NewJSONLinesFileSink opens (or creates with 0600 permissions) the file at path
+// for appending. onError, when not nil, is called when an event can not be written.
+func NewJSONLinesFileSink(path string, onError func(err error)) (*JSONLinesFileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLinesFileSink{
+		file:    file,
+		encoder: json.NewEncoder(file),
+		onError: onError,
+	}, nil
+}
+
+// Record writes event as a JSON line.
+func (sink *JSONLinesFileSink) Record(event Event) {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	if err := sink.encoder.Encode(event); err != nil && sink.onError != nil {
+		sink.onError(err)
+	}
+}
+
+// Close closes the underlying file.
+func (sink *JSONLinesFileSink) Close() error {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	return sink.file.Close()
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package audit implements an audit trail of secret accesses.
+// Unit tests for audit package.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONLinesFileSink(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewJSONLinesFileSink(path, func(err error) {
+		t.Errorf("unexpected write error: %v", err)
+	})
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	trail := NewTrail(sink, "ci-pipeline")
+	trail.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	trail.Record(OperationRead, "folder/title", "9152f5b6-07d6-4955-175a-08db047219ce", nil)
+	trail.Record(OperationDownload, "folder/file", "", errors.New("error - status code: 404"))
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("audit file permissions %v, expected 0600", info.Mode().Perm())
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	expected := []Event{
+		{Timestamp: trail.now(), Principal: "ci-pipeline", Operation: OperationRead, Path: "folder/title", SecretID: "9152f5b6-07d6-4955-175a-08db047219ce", Outcome: OutcomeSuccess},
+		{Timestamp: trail.now(), Principal: "ci-pipeline", Operation: OperationDownload, Path: "folder/file", Outcome: OutcomeFailure, Error: "error - status code: 404"},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %v", len(expected), events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("event %d: got %+v, expected %+v", i, events[i], expected[i])
+		}
+	}
+}
+
+func TestNilTrail(t *testing.T) {
+	// must not panic, API objects hold a nil trail unless one is configured.
+	var trail *Trail
+	trail.Record(OperationDelete, "", "1", nil)
+
+	NewTrail(nil, "principal").Record(OperationDelete, "", "1", nil)
+}
@@ -62,6 +62,7 @@ func Authenticate(authenticationParametersObj AuthenticationParametersObj) (*Aut
 		log:                authenticationParametersObj.Logger,
 	}
 
+	logging.RegisterSecret(authenticationObj.log, authenticationParametersObj.ClientSecret)
 	authenticationObj.log.Debug("Signing in using Oauth")
 	return authenticationObj, nil
 }
@@ -84,6 +85,7 @@ func AuthenticateUsingApiKey(authenticationParametersObj AuthenticationParameter
 		ExponentialBackOff: authenticationParametersObj.BackoffDefinition,
 		log:                authenticationParametersObj.Logger,
 	}
+	logging.RegisterSecret(authenticationObj.log, authenticationParametersObj.ApiKey)
 	authenticationObj.log.Debug("Signing in using API Key")
 	return authenticationObj, nil
 }
@@ -149,10 +151,10 @@ func (authenticationObj *AuthenticationObj) GetTokenDetails(endpointUrl string,
 	messageLog := fmt.Sprintf("%v %v", "POST", endpointUrl)
 	authenticationObj.log.Debug(messageLog)
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.GetTokenResponse{}, technicalError
@@ -174,6 +176,7 @@ func (authenticationObj *AuthenticationObj) GetTokenDetails(endpointUrl string,
 		return entities.GetTokenResponse{}, err
 	}
 
+	logging.RegisterSecret(authenticationObj.log, data.AccessToken)
 	authenticationObj.log.Debug("Successfully retrieved token details")
 
 	return data, nil
@@ -203,13 +206,13 @@ func (authenticationObj *AuthenticationObj) SignAppin(endpointUrl string, access
 	messageLog := fmt.Sprintf("%v %v", "POST", endpointUrl)
 	authenticationObj.log.Debug(messageLog)
 
-	err := backoff.Retry(func() error {
+	err := authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, authenticationObj.ExponentialBackOff, func() error {
 		body, scode, technicalError, businessError = authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		if scode == 0 {
 			return nil
 		}
 		return technicalError
-	}, authenticationObj.ExponentialBackOff)
+	})
 
 	if err != nil {
 		return entities.SignAppinResponse{}, err
@@ -264,10 +267,10 @@ func (authenticationObj *AuthenticationObj) SignOut() error {
 	messageLog := fmt.Sprintf("%v %v", "POST", signOutUrl)
 	authenticationObj.log.Debug(messageLog)
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return technicalError
@@ -0,0 +1,426 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package config loads the client settings from a file, environment variables and options and builds a ready client.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	backoff "github.com/cenkalti/backoff/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings needed to connect to Password Safe.
+//
+// Load applies the sources in this order, each overriding the previous one:
+// Default, the config file, the PASSWORD_SAFE_* environment variables and the options.
+// CertificatePins are SPKI pins ("sha256/<base64>"), see utils.WithSPKIPins.
+// RetryMaxAttempts caps the attempts of a call, 0 means no cap, see utils.RetryPolicy.
+// CircuitBreakerThreshold enables the circuit breaker, opened for CircuitBreakerOpenSeconds
+// (30 by default), see utils.WithCircuitBreaker.
+// RateLimit is the client request budget in requests per second, 0 means no limit,
+// RateLimitBurst defaults to the rate rounded up, see utils.WithRateLimit.
+type Config struct {
+	ApiUrl                     string   `json:"apiUrl" yaml:"apiUrl"`
+	ApiVersion                 string   `json:"apiVersion" yaml:"apiVersion"`
+	ClientId                   string   `json:"clientId" yaml:"clientId"`
+	ClientSecret               string   `json:"clientSecret" yaml:"clientSecret"`
+	ApiKey                     string   `json:"apiKey" yaml:"apiKey"`
+	Certificate                string   `json:"certificate" yaml:"certificate"`
+	CertificateKey             string   `json:"certificateKey" yaml:"certificateKey"`
+	CertificateKeyPassword     string   `json:"certificateKeyPassword" yaml:"certificateKeyPassword"`
+	CertificatePath            string   `json:"certificatePath" yaml:"certificatePath"`
+	CertificateName            string   `json:"certificateName" yaml:"certificateName"`
+	CertificatePassword        string   `json:"certificatePassword" yaml:"certificatePassword"`
+	VerifyCa                   bool     `json:"verifyCa" yaml:"verifyCa"`
+	RootCAs                    string   `json:"rootCAs" yaml:"rootCAs"`
+	RootCAsFile                string   `json:"rootCAsFile" yaml:"rootCAsFile"`
+	CertificatePins            []string `json:"certificatePins" yaml:"certificatePins"`
+	ClientTimeOutInSeconds     int      `json:"clientTimeOutInSeconds" yaml:"clientTimeOutInSeconds"`
+	RetryMaxElapsedTimeMinutes int      `json:"retryMaxElapsedTimeMinutes" yaml:"retryMaxElapsedTimeMinutes"`
+	RetryMaxAttempts           int      `json:"retryMaxAttempts" yaml:"retryMaxAttempts"`
+	Separator                  string   `json:"separator" yaml:"separator"`
+	MaxFileSecretSizeBytes     int      `json:"maxFileSecretSizeBytes" yaml:"maxFileSecretSizeBytes"`
+	RateLimit                  float64  `json:"rateLimit" yaml:"rateLimit"`
+	RateLimitBurst             int      `json:"rateLimitBurst" yaml:"rateLimitBurst"`
+	CircuitBreakerThreshold    int      `json:"circuitBreakerThreshold" yaml:"circuitBreakerThreshold"`
+	CircuitBreakerOpenSeconds  int      `json:"circuitBreakerOpenSeconds" yaml:"circuitBreakerOpenSeconds"`
+}
+
+// Option changes a Config, options are applied last by Load.
+type Option func(config *Config)
+
+// Environment variables read by LoadEnv.
+const (
+	EnvApiUrl                     = "PASSWORD_SAFE_API_URL"
+	EnvApiVersion                 = "PASSWORD_SAFE_API_VERSION"
+	EnvClientId                   = "PASSWORD_SAFE_CLIENT_ID"
+	EnvClientSecret               = "PASSWORD_SAFE_CLIENT_SECRET"
+	EnvApiKey                     = "PASSWORD_SAFE_API_KEY"
+	EnvCertificate                = "PASSWORD_SAFE_CERTIFICATE"
+	EnvCertificateKey             = "PASSWORD_SAFE_CERTIFICATE_KEY"
+	EnvCertificateKeyPassword     = "PASSWORD_SAFE_CERTIFICATE_KEY_PASSWORD"
+	EnvCertificatePath            = "PASSWORD_SAFE_CERTIFICATE_PATH"
+	EnvCertificateName            = "PASSWORD_SAFE_CERTIFICATE_NAME"
+	EnvCertificatePassword        = "PASSWORD_SAFE_CERTIFICATE_PASSWORD"
+	EnvVerifyCa                   = "PASSWORD_SAFE_VERIFY_CA"
+	EnvRootCAs                    = "PASSWORD_SAFE_ROOT_CAS"
+	EnvRootCAsFile                = "PASSWORD_SAFE_ROOT_CAS_FILE"
+	EnvCertificatePins            = "PASSWORD_SAFE_CERTIFICATE_PINS"
+	EnvClientTimeOutInSeconds     = "PASSWORD_SAFE_CLIENT_TIMEOUT_SECONDS"
+	EnvRetryMaxElapsedTimeMinutes = "PASSWORD_SAFE_RETRY_MAX_ELAPSED_TIME_MINUTES"
+	EnvRetryMaxAttempts           = "PASSWORD_SAFE_RETRY_MAX_ATTEMPTS"
+	EnvSeparator                  = "PASSWORD_SAFE_SEPARATOR"
+	EnvMaxFileSecretSizeBytes     = "PASSWORD_SAFE_MAX_FILE_SECRET_SIZE_BYTES"
+	EnvRateLimit                  = "PASSWORD_SAFE_RATE_LIMIT"
+	EnvRateLimitBurst             = "PASSWORD_SAFE_RATE_LIMIT_BURST"
+	EnvCircuitBreakerThreshold    = "PASSWORD_SAFE_CIRCUIT_BREAKER_THRESHOLD"
+	EnvCircuitBreakerOpenSeconds  = "PASSWORD_SAFE_CIRCUIT_BREAKER_OPEN_SECONDS"
+)
+
+// Default returns the settings used when nothing else is configured.
+func Default() Config {
+	return Config{
+		ApiVersion:                 "3.1",
+		VerifyCa:                   true,
+		ClientTimeOutInSeconds:     30,
+		RetryMaxElapsedTimeMinutes: 2,
+		Separator:                  "/",
+		MaxFileSecretSizeBytes:     4000,
+	}
+}
+
+// Load returns Default overridden by the file at path (skipped when path is empty),
+// the environment variables and options.
+func Load(path string, options ...Option) (Config, error) {
+	config := Default()
+
+	if path != "" {
+		if err := config.LoadFile(path); err != nil {
+			return config, err
+		}
+	}
+
+	if err := config.LoadEnv(); err != nil {
+		return config, err
+	}
+
+	config.Apply(options...)
+	return config, nil
+}
+
+// Apply applies options to config.
+func (config *Config) Apply(options ...Option) {
+	for _, option := range options {
+		option(config)
+	}
+}
+
+// LoadFile overrides config with the values set in a JSON or YAML file.
+func (config *Config) LoadFile(path string) error {
+	return DecodeFile(path, config)
+}
+
+// DecodeFile decodes a JSON file, or a YAML file when the extension is .yaml or .yml, into value.
+func DecodeFile(path string, value interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, value)
+	default:
+		err = json.Unmarshal(content, value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid config file %v: %w", path, err)
+	}
+	return nil
+}
+
+// LoadEnv overrides config with the environment variables that are set.
+func (config *Config) LoadEnv() error {
+	stringValues := map[string]*string{
+		EnvApiUrl:                 &config.ApiUrl,
+		EnvApiVersion:             &config.ApiVersion,
+		EnvClientId:               &config.ClientId,
+		EnvClientSecret:           &config.ClientSecret,
+		EnvApiKey:                 &config.ApiKey,
+		EnvCertificate:            &config.Certificate,
+		EnvCertificateKey:         &config.CertificateKey,
+		EnvCertificateKeyPassword: &config.CertificateKeyPassword,
+		EnvCertificatePath:        &config.CertificatePath,
+		EnvCertificateName:        &config.CertificateName,
+		EnvCertificatePassword:    &config.CertificatePassword,
+		EnvRootCAs:                &config.RootCAs,
+		EnvRootCAsFile:            &config.RootCAsFile,
+		EnvSeparator:              &config.Separator,
+	}
+	for name, value := range stringValues {
+		if env, ok := os.LookupEnv(name); ok {
+			*value = env
+		}
+	}
+
+	intValues := map[string]*int{
+		EnvClientTimeOutInSeconds:     &config.ClientTimeOutInSeconds,
+		EnvRetryMaxElapsedTimeMinutes: &config.RetryMaxElapsedTimeMinutes,
+		EnvRetryMaxAttempts:           &config.RetryMaxAttempts,
+		EnvMaxFileSecretSizeBytes:     &config.MaxFileSecretSizeBytes,
+		EnvRateLimitBurst:             &config.RateLimitBurst,
+		EnvCircuitBreakerThreshold:    &config.CircuitBreakerThreshold,
+		EnvCircuitBreakerOpenSeconds:  &config.CircuitBreakerOpenSeconds,
+	}
+	for name, value := range intValues {
+		if env, ok := os.LookupEnv(name); ok {
+			number, err := strconv.Atoi(env)
+			if err != nil {
+				return fmt.Errorf("invalid %v: %w", name, err)
+			}
+			*value = number
+		}
+	}
+
+	if env, ok := os.LookupEnv(EnvRateLimit); ok {
+		rateLimit, err := strconv.ParseFloat(env, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", EnvRateLimit, err)
+		}
+		config.RateLimit = rateLimit
+	}
+
+	// Pins are separated by commas.
+	if env, ok := os.LookupEnv(EnvCertificatePins); ok {
+		config.CertificatePins = nil
+		for _, pin := range strings.Split(env, ",") {
+			if pin = strings.TrimSpace(pin); pin != "" {
+				config.CertificatePins = append(config.CertificatePins, pin)
+			}
+		}
+	}
+
+	if env, ok := os.LookupEnv(EnvVerifyCa); ok {
+		verifyCa, err := strconv.ParseBool(env)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", EnvVerifyCa, err)
+		}
+		config.VerifyCa = verifyCa
+	}
+	return nil
+}
+
+// WithApiUrl sets the Password Safe API URL.
+func WithApiUrl(apiUrl string) Option {
+	return func(config *Config) { config.ApiUrl = apiUrl }
+}
+
+// WithApiVersion sets the Password Safe API version.
+func WithApiVersion(apiVersion string) Option {
+	return func(config *Config) { config.ApiVersion = apiVersion }
+}
+
+// WithClientCredentials sets the API OAuth client ID and secret.
+func WithClientCredentials(clientId string, clientSecret string) Option {
+	return func(config *Config) {
+		config.ClientId = clientId
+		config.ClientSecret = clientSecret
+	}
+}
+
+// WithApiKey sets the API key used instead of client credentials.
+func WithApiKey(apiKey string) Option {
+	return func(config *Config) { config.ApiKey = apiKey }
+}
+
+// WithCertificate sets the PEM client certificate and key.
+func WithCertificate(certificate string, certificateKey string) Option {
+	return func(config *Config) {
+		config.Certificate = certificate
+		config.CertificateKey = certificateKey
+	}
+}
+
+// WithCertificateKeyPassword sets the password of an encrypted PKCS #8 certificate key.
+func WithCertificateKeyPassword(certificateKeyPassword string) Option {
+	return func(config *Config) { config.CertificateKeyPassword = certificateKeyPassword }
+}
+
+// WithPFXCertificate sets the pfx file the client certificate is read from.
+func WithPFXCertificate(certificatePath string, certificateName string, certificatePassword string) Option {
+	return func(config *Config) {
+		config.CertificatePath = certificatePath
+		config.CertificateName = certificateName
+		config.CertificatePassword = certificatePassword
+	}
+}
+
+// WithVerifyCa sets whether the server certificate authority is verified.
+func WithVerifyCa(verifyCa bool) Option {
+	return func(config *Config) { config.VerifyCa = verifyCa }
+}
+
+// WithRootCAsFile sets the PEM file of the CA certificates trusted instead of the system roots.
+func WithRootCAsFile(path string) Option {
+	return func(config *Config) { config.RootCAsFile = path }
+}
+
+// WithCertificatePins sets the SPKI pins the server must present.
+func WithCertificatePins(pins ...string) Option {
+	return func(config *Config) { config.CertificatePins = pins }
+}
+
+// WithRateLimit sets the client request budget in requests per second and its burst.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(config *Config) {
+		config.RateLimit = requestsPerSecond
+		config.RateLimitBurst = burst
+	}
+}
+
+// WithCircuitBreaker opens the circuit after threshold consecutive failures for openSeconds.
+func WithCircuitBreaker(threshold int, openSeconds int) Option {
+	return func(config *Config) {
+		config.CircuitBreakerThreshold = threshold
+		config.CircuitBreakerOpenSeconds = openSeconds
+	}
+}
+
+// WithClientTimeOut sets the request timeout in seconds.
+func WithClientTimeOut(seconds int) Option {
+	return func(config *Config) { config.ClientTimeOutInSeconds = seconds }
+}
+
+// WithRetryMaxElapsedTime sets how long failed requests are retried, in minutes.
+func WithRetryMaxElapsedTime(minutes int) Option {
+	return func(config *Config) { config.RetryMaxElapsedTimeMinutes = minutes }
+}
+
+// WithRetryMaxAttempts caps the attempts of a call, 0 means no cap.
+func WithRetryMaxAttempts(attempts int) Option {
+	return func(config *Config) { config.RetryMaxAttempts = attempts }
+}
+
+// WithSeparator sets the separator of secret and managed account paths.
+func WithSeparator(separator string) Option {
+	return func(config *Config) { config.Separator = separator }
+}
+
+// WithMaxFileSecretSizeBytes sets the maximum size of file secrets.
+func WithMaxFileSecretSizeBytes(maxFileSecretSizeBytes int) Option {
+	return func(config *Config) { config.MaxFileSecretSizeBytes = maxFileSecretSizeBytes }
+}
+
+// Validate resolves the pfx certificate, when one is set, and validates the settings.
+func (config *Config) Validate(logger logging.Logger) error {
+	if config.CertificateName != "" {
+		certificate, certificateKey, err := utils.GetPFXContent(config.CertificatePath, config.CertificateName, config.CertificatePassword, logger)
+		if err != nil {
+			return err
+		}
+		config.Certificate, config.CertificateKey = certificate, certificateKey
+		config.CertificateName = ""
+	}
+
+	return utils.ValidateInputs(utils.ValidationParams{
+		ApiKey:                     config.ApiKey,
+		ClientID:                   config.ClientId,
+		ClientSecret:               config.ClientSecret,
+		ApiUrl:                     &config.ApiUrl,
+		ApiVersion:                 config.ApiVersion,
+		ClientTimeOutInSeconds:     config.ClientTimeOutInSeconds,
+		Separator:                  &config.Separator,
+		VerifyCa:                   config.VerifyCa,
+		Logger:                     logger,
+		Certificate:                config.Certificate,
+		CertificateKey:             config.CertificateKey,
+		RetryMaxElapsedTimeMinutes: &config.RetryMaxElapsedTimeMinutes,
+		MaxFileSecretSizeBytes:     &config.MaxFileSecretSizeBytes,
+	})
+}
+
+// BackoffDefinition returns the retry policy for the configured maximum elapsed time.
+func (config Config) BackoffDefinition() *backoff.ExponentialBackOff {
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.InitialInterval = 1 * time.Second
+	backoffDefinition.MaxElapsedTime = time.Duration(config.RetryMaxElapsedTimeMinutes) * time.Minute
+	backoffDefinition.RandomizationFactor = 0.5
+	return backoffDefinition
+}
+
+// httpClientOptions returns the HTTP client options for the settings.
+func (config Config) httpClientOptions() []utils.HttpClientOption {
+	options := []utils.HttpClientOption{
+		utils.WithTimeout(time.Duration(config.ClientTimeOutInSeconds) * time.Second),
+		utils.WithVerifyCa(config.VerifyCa),
+		utils.WithEncryptedClientCertificate(config.Certificate, config.CertificateKey, config.CertificateKeyPassword),
+	}
+	if config.RootCAs != "" {
+		options = append(options, utils.WithRootCAsPEM(config.RootCAs))
+	}
+	if config.RootCAsFile != "" {
+		options = append(options, utils.WithRootCAsFile(config.RootCAsFile))
+	}
+	if len(config.CertificatePins) > 0 {
+		options = append(options, utils.WithSPKIPins(config.CertificatePins...))
+	}
+	if config.RetryMaxAttempts != 0 {
+		options = append(options, utils.WithRetryPolicy(utils.RetryPolicy{MaxAttempts: config.RetryMaxAttempts}))
+	}
+	if config.CircuitBreakerThreshold != 0 {
+		openSeconds := config.CircuitBreakerOpenSeconds
+		if openSeconds == 0 {
+			openSeconds = 30
+		}
+		options = append(options, utils.WithCircuitBreaker(config.CircuitBreakerThreshold, time.Duration(openSeconds)*time.Second))
+	}
+	if config.RateLimit > 0 {
+		burst := config.RateLimitBurst
+		if burst == 0 {
+			burst = int(math.Ceil(config.RateLimit))
+		}
+		options = append(options, utils.WithRateLimit(config.RateLimit, burst))
+	}
+	return options
+}
+
+// NewAuthenticationObj validates the settings and returns an authentication object using
+// the API key when one is set and the client credentials otherwise. Call
+// GetPasswordSafeAuthentication on it to sign in.
+func (config Config) NewAuthenticationObj(logger logging.Logger) (*authentication.AuthenticationObj, error) {
+	if err := config.Validate(logger); err != nil {
+		return nil, err
+	}
+
+	httpClientObj, err := utils.NewHttpClient(logger, config.httpClientOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	authParams := authentication.AuthenticationParametersObj{
+		HTTPClient:                 *httpClientObj,
+		BackoffDefinition:          config.BackoffDefinition(),
+		EndpointURL:                config.ApiUrl,
+		APIVersion:                 config.ApiVersion,
+		ClientID:                   config.ClientId,
+		ClientSecret:               config.ClientSecret,
+		ApiKey:                     config.ApiKey,
+		Logger:                     logger,
+		RetryMaxElapsedTimeSeconds: config.RetryMaxElapsedTimeMinutes * 60,
+	}
+
+	if config.ApiKey != "" {
+		return authentication.AuthenticateUsingApiKey(authParams)
+	}
+	return authentication.Authenticate(authParams)
+}
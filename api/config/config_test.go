@@ -0,0 +1,157 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package config loads the client settings from a file, environment variables and options and builds a ready client.
+// Unit tests for config package.
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	"go.uber.org/zap"
+)
+
+var zapLogger = logging.NewZapLogger(zap.NewNop())
+
+const (
+	fakeApiUrl       = "https://example.com:443/BeyondTrust/api/public/v3"
+	fakeClientId     = "6138d050-e266-4b05-9ced-35e7dd5093ae"
+	fakeClientSecret = "71eec5f0-a07c-4b10-b0d5-8c5d2f1e2ec3"
+)
+
+// clearEnv unsets the config environment variables for the duration of the test.
+func clearEnv(t *testing.T) {
+	for _, name := range []string{EnvApiUrl, EnvApiVersion, EnvClientId, EnvClientSecret, EnvApiKey, EnvCertificate, EnvCertificateKey, EnvCertificateKeyPassword,
+		EnvCertificatePath, EnvCertificateName, EnvCertificatePassword, EnvVerifyCa, EnvClientTimeOutInSeconds,
+		EnvRetryMaxElapsedTimeMinutes, EnvSeparator, EnvMaxFileSecretSizeBytes, EnvRootCAs, EnvRootCAsFile, EnvCertificatePins, EnvRateLimit, EnvRateLimitBurst, EnvRetryMaxAttempts,
+		EnvCircuitBreakerThreshold, EnvCircuitBreakerOpenSeconds} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "config.yaml", "apiUrl: "+fakeApiUrl+"\nclientId: file-client\nclientSecret: file-secret\nseparator: \"-\"\nclientTimeOutInSeconds: 10\n")
+	t.Setenv(EnvClientId, "env-client")
+	t.Setenv(EnvSeparator, "|")
+	t.Setenv(EnvVerifyCa, "false")
+	t.Setenv(EnvCertificatePins, "sha256/pin1, sha256/pin2")
+	t.Setenv(EnvRateLimit, "2.5")
+	t.Setenv(EnvRetryMaxAttempts, "3")
+	t.Setenv(EnvCircuitBreakerThreshold, "5")
+
+	config, err := Load(path, WithSeparator("+"))
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	expected := Default()
+	expected.ApiUrl = fakeApiUrl
+	expected.ClientId = "env-client"
+	expected.ClientSecret = "file-secret"
+	expected.Separator = "+"
+	expected.ClientTimeOutInSeconds = 10
+	expected.VerifyCa = false
+	expected.CertificatePins = []string{"sha256/pin1", "sha256/pin2"}
+	expected.RateLimit = 2.5
+	expected.RetryMaxAttempts = 3
+	expected.CircuitBreakerThreshold = 5
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("Test case Failed %+v, expected %+v", config, expected)
+	}
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "config.json", `{"apiUrl": "`+fakeApiUrl+`", "apiKey": "key", "maxFileSecretSizeBytes": 100}`)
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if config.ApiUrl != fakeApiUrl || config.ApiKey != "key" || config.MaxFileSecretSizeBytes != 100 || config.ApiVersion != "3.1" {
+		t.Errorf("Test case Failed %+v", config)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	clearEnv(t)
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("a missing config file must fail")
+	}
+
+	if _, err := Load(writeFile(t, "config.json", `{"apiUrl": `)); err == nil {
+		t.Error("an invalid config file must fail")
+	}
+
+	t.Setenv(EnvRateLimit, "fast")
+	if _, err := Load(""); err == nil {
+		t.Error("an invalid rate limit must fail")
+	}
+	os.Unsetenv(EnvRateLimit)
+
+	t.Setenv(EnvClientTimeOutInSeconds, "soon")
+	if _, err := Load(""); err == nil || err.Error() != `invalid PASSWORD_SAFE_CLIENT_TIMEOUT_SECONDS: strconv.Atoi: parsing "soon": invalid syntax` {
+		t.Errorf("Test case Failed %v", err)
+	}
+}
+
+func TestNewAuthenticationObj(t *testing.T) {
+	clearEnv(t)
+
+	config, _ := Load("", WithApiUrl(fakeApiUrl), WithClientCredentials(fakeClientId, fakeClientSecret), WithRetryMaxElapsedTime(0))
+	authenticationObj, err := config.NewAuthenticationObj(zapLogger)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if authenticationObj.ApiUrl.String() != fakeApiUrl || authenticationObj.ApiVersion != "3.1" {
+		t.Errorf("Test case Failed %v %v", authenticationObj.ApiUrl.String(), authenticationObj.ApiVersion)
+	}
+
+	config.Apply(WithApiUrl("http://example.com"))
+	if _, err = config.NewAuthenticationObj(zapLogger); err == nil {
+		t.Error("an invalid API URL must fail")
+	}
+
+	config, _ = Load("", WithApiUrl(fakeApiUrl), WithClientCredentials(fakeClientId, fakeClientSecret), WithCertificatePins("sha256/invalid"))
+	if _, err = config.NewAuthenticationObj(zapLogger); err == nil {
+		t.Error("an invalid certificate pin must fail")
+	}
+
+	config, _ = Load("", WithApiUrl(fakeApiUrl), WithClientCredentials(fakeClientId, fakeClientSecret), WithRateLimit(0.5, 0))
+	if _, err = config.NewAuthenticationObj(zapLogger); err != nil {
+		t.Errorf("a rate limit without burst must default the burst: %v", err)
+	}
+
+	config, _ = Load("", WithApiUrl(fakeApiUrl), WithClientCredentials(fakeClientId, fakeClientSecret), WithRetryMaxAttempts(-1))
+	if _, err = config.NewAuthenticationObj(zapLogger); err == nil {
+		t.Error("negative retry max attempts must fail")
+	}
+
+	config, _ = Load("", WithApiUrl(fakeApiUrl), WithClientCredentials(fakeClientId, fakeClientSecret), WithCircuitBreaker(5, 0))
+	authenticationObj, err = config.NewAuthenticationObj(zapLogger)
+	if err != nil {
+		t.Fatalf("a circuit breaker without open time must default it: %v", err)
+	}
+	if authenticationObj.HttpClient.CircuitState() != utils.CircuitClosed {
+		t.Errorf("Test case Failed %v", authenticationObj.HttpClient.CircuitState())
+	}
+
+	config, _ = Load("", WithApiUrl(fakeApiUrl), WithPFXCertificate(t.TempDir(), "missing.pfx", ""))
+	if err = config.Validate(zapLogger); err == nil {
+		t.Error("a missing pfx certificate must fail")
+	}
+}
@@ -14,15 +14,18 @@ const (
 	SecretDeleteFolder     = "SecretDeleteFolder"
 	SecretDeleteSafe       = "SecretDeleteSafe"
 	SecretGetSecretByTitle = "SecretGetSecretByTitle"
+	SecretGetSecretsList   = "SecretGetSecretsList"
 
 	ManagedAccountGet    = "ManagedAccountGet"
 	ManagedAccountCreate = "ManagedAccountCreate"
 	ManagedAccountDelete = "ManagedAccountDelete"
 
 	ManagedAccountCreateRequest        = "ManagedAccountCreateRequest"
+	ManagedAccountGetRequests          = "ManagedAccountGetRequests"
 	CredentialByRequestId              = "CredentialByRequestId"
 	ManagedAccountRequestCheckIn       = "ManagedAccountRequestCheckIn"
 	ManagedAccountCreateManagedAccount = "ManagedAccountCreateManagedAccount"
+	ManagedAccountChangeCredentials    = "ManagedAccountChangeCredentials"
 	ManagedSystemGetSystems            = "ManagedSystemGetSystems"
 
 	CreateMultiPartRequest = "CreateMultiPartRequest"
@@ -54,4 +57,33 @@ const (
 	GetFunctionalAccount    = "GetFunctionalAccount"
 
 	GetPlatformsList = "GetPlatformsList"
+
+	CreateUser   = "CreateUser"
+	DeleteUser   = "DeleteUser"
+	GetUser      = "GetUser"
+	GetUsersList = "GetUsersList"
+
+	CreateUserGroup            = "CreateUserGroup"
+	DeleteUserGroup            = "DeleteUserGroup"
+	GetUserGroup               = "GetUserGroup"
+	GetUserGroupsList          = "GetUserGroupsList"
+	GetUserGroupMembers        = "GetUserGroupMembers"
+	AddUserGroupMember         = "AddUserGroupMember"
+	RemoveUserGroupMember      = "RemoveUserGroupMember"
+	GetUserGroupPermissions    = "GetUserGroupPermissions"
+	SetUserGroupPermissions    = "SetUserGroupPermissions"
+	DeleteUserGroupPermissions = "DeleteUserGroupPermissions"
+	GetSmartRuleRoles          = "GetSmartRuleRoles"
+	SetSmartRuleRoles          = "SetSmartRuleRoles"
+	DeleteSmartRuleRoles       = "DeleteSmartRuleRoles"
+
+	GetAccessPoliciesList = "GetAccessPoliciesList"
+	TestAccessPolicy      = "TestAccessPolicy"
+	GetRolesList          = "GetRolesList"
+
+	CreateSmartRule                = "CreateSmartRule"
+	DeleteSmartRule                = "DeleteSmartRule"
+	GetSmartRule                   = "GetSmartRule"
+	GetSmartRulesList              = "GetSmartRulesList"
+	GetSmartRuleManagedAccountList = "GetSmartRuleManagedAccountList"
 )
@@ -17,8 +17,6 @@ import (
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
 
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
-
-	backoff "github.com/cenkalti/backoff/v4"
 )
 
 type DatabaseObj struct {
@@ -106,10 +104,10 @@ func (databaseObj *DatabaseObj) createDatabase(assetId string, database entities
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = databaseObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, databaseObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = databaseObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, databaseObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.DatabaseResponse{}, technicalError
@@ -39,6 +39,43 @@ type ManagedAccount struct {
 	AccountDescription     string
 }
 
+// ManagedAccountCheckout holds a credential whose request stays open until it is checked in.
+type ManagedAccountCheckout struct {
+	SystemName  string
+	AccountName string
+	SystemId    int
+	AccountId   int
+	RequestId   string
+	Password    string `json:"-"`
+}
+
+// ManagedAccountSSHKey holds the SSH private key of a managed account.
+type ManagedAccountSSHKey struct {
+	SystemName  string
+	AccountName string
+	SystemId    int
+	AccountId   int
+	PrivateKey  string `json:"-"`
+	// Passphrase decrypts PrivateKey, empty when the key is not encrypted.
+	Passphrase string `json:"-"`
+	// PublicKey is the public key in authorized_keys format.
+	PublicKey string
+	// RequestId is the request left open by ManageAccountSSHKeyCheckoutFlow.
+	RequestId string
+}
+
+// ManagedAccountRequest responsible for the Requests list response data.
+type ManagedAccountRequest struct {
+	RequestID   int
+	SystemID    int
+	SystemName  string
+	AccountID   int
+	AccountName string
+	Status      string
+	AccessType  string
+	ExpiresDate string
+}
+
 // Secret responsible for secrets-safe response data.
 type Secret struct {
 	Id         string
@@ -66,6 +103,22 @@ type CreateManagedAccountsResponse struct {
 	AccountName      string
 }
 
+// ManagedAccountDetailsResponse is the managed account returned by ManagedAccounts/{id}.
+type ManagedAccountDetailsResponse struct {
+	ManagedAccountID       int
+	ManagedSystemID        int
+	AccountName            string
+	DomainName             string
+	UserPrincipalName      string
+	Description            string
+	DefaultReleaseDuration int
+	MaximumReleaseDuration int
+	LastChangeDate         string
+	NextChangeDate         string
+	IsChanging             bool
+	ChangeState            int
+}
+
 type AccountDetails struct {
 	AccountName                       string `validate:"required,max=245"`
 	Password                          string `validate:"required_if=AutoManagementFlag false"`
@@ -569,3 +622,191 @@ type PlatformResponse struct {
 	RequiresObjectID        bool
 	RequiresSecret          bool
 }
+
+type UserDetailsBase struct {
+	UserType string `json:"UserType"`
+}
+
+type UserBeyondInsightDetails struct {
+	UserDetailsBase
+	UserName     string `json:"UserName" validate:"required,max=64"`
+	FirstName    string `json:"FirstName" validate:"required,max=64"`
+	LastName     string `json:"LastName,omitempty" validate:"omitempty,max=64"`
+	EmailAddress string `json:"EmailAddress" validate:"required,email,max=255"`
+	Password     string `json:"Password" validate:"required"`
+}
+
+type UserActiveDirectoryDetails struct {
+	UserDetailsBase
+	UserName     string `json:"UserName" validate:"required,max=64"`
+	ForestName   string `json:"ForestName,omitempty" validate:"omitempty,max=300"`
+	DomainName   string `json:"DomainName" validate:"required,max=250"`
+	BindUser     string `json:"BindUser,omitempty" validate:"omitempty,max=64"`
+	BindPassword string `json:"BindPassword,omitempty" validate:"required_with=BindUser"`
+	UseSSL       bool   `json:"UseSSL"`
+}
+
+type UserLdapDetails struct {
+	UserDetailsBase
+	HostName             string `json:"HostName" validate:"required,max=50"`
+	DistinguishedName    string `json:"DistinguishedName" validate:"required,max=255"`
+	AccountNameAttribute string `json:"AccountNameAttribute" validate:"required,max=255"`
+	BindUser             string `json:"BindUser,omitempty" validate:"omitempty,max=64"`
+	BindPassword         string `json:"BindPassword,omitempty" validate:"required_with=BindUser"`
+	Port                 int    `json:"Port,omitempty" validate:"omitempty,gte=1,lte=65535"`
+	UseSSL               bool   `json:"UseSSL"`
+}
+
+type UserResponse struct {
+	UserID            int
+	UserName          string
+	DomainName        string
+	DistinguishedName string
+	FirstName         string
+	LastName          string
+	EmailAddress      string
+	IsQuarantined     bool
+}
+
+type UserGroupPermission struct {
+	PermissionID  int `json:"PermissionID" validate:"required"`
+	AccessLevelID int `json:"AccessLevelID" validate:"required"`
+}
+
+type UserGroupSmartRuleAccess struct {
+	SmartRuleID   int `json:"SmartRuleID" validate:"required"`
+	AccessLevelID int `json:"AccessLevelID" validate:"required"`
+}
+
+type UserGroupDetailsBase struct {
+	GroupType                  string                     `json:"groupType"`
+	GroupName                  string                     `json:"groupName" validate:"required,max=200"`
+	Description                string                     `json:"description,omitempty" validate:"omitempty,max=255"`
+	IsActive                   bool                       `json:"isActive"`
+	Permissions                []UserGroupPermission      `json:"Permissions,omitempty" validate:"omitempty,dive"`
+	SmartRuleAccess            []UserGroupSmartRuleAccess `json:"SmartRuleAccess,omitempty" validate:"omitempty,dive"`
+	ApplicationRegistrationIDs []int                      `json:"ApplicationRegistrationIDs,omitempty"`
+}
+
+type UserGroupBeyondInsightDetails struct {
+	UserGroupDetailsBase
+}
+
+type UserGroupActiveDirectoryDetails struct {
+	UserGroupDetailsBase
+	ForestName   string `json:"forestName,omitempty" validate:"omitempty,max=300"`
+	DomainName   string `json:"domainName" validate:"required,max=250"`
+	BindUser     string `json:"bindUser,omitempty" validate:"omitempty,max=64"`
+	BindPassword string `json:"bindPassword,omitempty" validate:"required_with=BindUser"`
+	UseSSL       bool   `json:"useSSL"`
+}
+
+type UserGroupLdapDetails struct {
+	UserGroupDetailsBase
+	GroupDistinguishedName string `json:"groupDistinguishedName" validate:"required,max=255"`
+	HostName               string `json:"hostName" validate:"required,max=50"`
+	BindUser               string `json:"bindUser,omitempty" validate:"omitempty,max=64"`
+	BindPassword           string `json:"bindPassword,omitempty" validate:"required_with=BindUser"`
+	Port                   int    `json:"port,omitempty" validate:"omitempty,gte=1,lte=65535"`
+	UseSSL                 bool   `json:"useSSL"`
+	MembershipAttribute    string `json:"membershipAttribute" validate:"required,max=255"`
+	AccountAttribute       string `json:"accountAttribute" validate:"required,max=255"`
+}
+
+type UserGroupResponse struct {
+	GroupID             int
+	Name                string
+	DistinguishedName   string
+	GroupType           string
+	AccountAttribute    string
+	MembershipAttribute string
+	IsActive            bool
+}
+
+type UserGroupPermissionResponse struct {
+	PermissionID   int
+	PermissionName string
+	AccessLevelID  int
+}
+
+type SmartRuleRole struct {
+	RoleID int `json:"RoleID" validate:"required"`
+}
+
+type SmartRuleRolesDetails struct {
+	Roles          []SmartRuleRole `json:"Roles" validate:"required,min=1,dive"`
+	AccessPolicyID int             `json:"AccessPolicyID,omitempty"`
+}
+
+type SmartRuleRoleResponse struct {
+	RoleID int
+	Name   string
+}
+
+type AccessPolicyAccessType struct {
+	AccessType         string
+	IsSession          bool
+	RecordSession      bool
+	MinApprovers       int
+	MaxConcurrent      int
+	AutoApprove        bool
+	AllowAPIRejections bool
+}
+
+type AccessPolicySchedule struct {
+	ScheduleID  int
+	AccessTypes []AccessPolicyAccessType
+}
+
+type AccessPolicyResponse struct {
+	AccessPolicyID int
+	Name           string
+	Description    string
+	Schedule       []AccessPolicySchedule
+}
+
+type AccessPolicyTestDetails struct {
+	SystemId        int `json:"SystemId" validate:"required"`
+	AccountId       int `json:"AccountId" validate:"required"`
+	DurationMinutes int `json:"DurationMinutes" validate:"required,gte=1,lte=10079"`
+}
+
+type RoleResponse struct {
+	RoleID int
+	Name   string
+}
+
+type AccessGrantDetails struct {
+	UserGroupID      int      `validate:"required"`
+	SmartRuleID      int      `validate:"required"`
+	RoleNames        []string `validate:"required,min=1,dive,required"`
+	AccessPolicyName string   `validate:"omitempty,max=255"`
+}
+
+type SmartRuleManagedAccountDetails struct {
+	AccountID          int    `json:"AccountID" validate:"required"`
+	Title              string `json:"Title" validate:"required,max=75"`
+	Category           string `json:"Category,omitempty" validate:"omitempty,max=50"`
+	Description        string `json:"Description,omitempty" validate:"omitempty,max=255"`
+	ProcessImmediately bool   `json:"ProcessImmediately"`
+}
+
+type SmartRuleAssetAttributeDetails struct {
+	AttributeIDs       []int  `json:"AttributeIDs" validate:"required,min=1,dive,required"`
+	Title              string `json:"Title" validate:"required,max=75"`
+	Category           string `json:"Category,omitempty" validate:"omitempty,max=50"`
+	Description        string `json:"Description,omitempty" validate:"omitempty,max=255"`
+	ProcessImmediately bool   `json:"ProcessImmediately"`
+}
+
+type SmartRuleResponse struct {
+	SmartRuleID       int
+	OrganizationID    string
+	Title             string
+	Description       string
+	Category          string
+	Status            int
+	LastProcessedDate string
+	IsReadOnly        bool
+	RuleType          string
+}
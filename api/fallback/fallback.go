@@ -0,0 +1,216 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package fallback implements an encrypted on-disk store of last known good secret values.
+package fallback
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// Kind separates the paths of secrets and managed accounts in the store.
+type Kind string
+
+const (
+	KindSecret         Kind = "secret"
+	KindManagedAccount Kind = "managed_account"
+)
+
+// additionalData binds the ciphertext to the file format.
+var additionalData = []byte("passwordsafe-last-known-good-v1")
+
+// Result is a retrieved value.
+type Result struct {
+	Value string
+	// Stale is true when Password Safe could not be reached and Value is the last
+	// known good value, saved at SavedAt.
+	Stale   bool
+	SavedAt time.Time
+}
+
+// Values returns the values of results.
+func Values(results map[string]Result) map[string]string {
+	values := make(map[string]string, len(results))
+	for path, result := range results {
+		values[path] = result.Value
+	}
+	return values
+}
+
+// entry is a stored value.
+type entry struct {
+	Value   string    `json:"value"`
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// Store keeps the last known good values in a file encrypted with AES-GCM and
+// serves them when Password Safe can not be reached. A nil Store saves and serves
+// nothing, so API objects can hold one unconditionally. Store is goroutine-safe.
+type Store struct {
+	mu           sync.Mutex
+	path         string
+	aead         cipher.AEAD
+	maxStaleness time.Duration
+	log          logging.Logger
+	now          func() time.Time
+}
+
+// NewStore returns a store saved to the file at path, encrypted with key (16, 24 or
+// 32 bytes for AES-128, AES-192 or AES-256). Values older than maxStaleness are not served.
+func NewStore(path string, key []byte, maxStaleness time.Duration, logger logging.Logger) (*Store, error) {
+	if path == "" {
+		return nil, errors.New("fallback store path must not be empty")
+	}
+	if maxStaleness <= 0 {
+		return nil, errors.New("fallback store max staleness must be greater than 0")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fallback store key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		path:         path,
+		aead:         aead,
+		maxStaleness: maxStaleness,
+		log:          logger,
+		now:          time.Now,
+	}, nil
+}
+
+// Save stores values, keyed by path, replacing the previous values of the paths.
+// Errors are logged, a store that can not be saved must not fail the retrieval.
+func (store *Store) Save(kind Kind, values map[string]string) {
+	if store == nil || len(values) == 0 {
+		return
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	entries, err := store.read()
+	if err != nil {
+		// A file encrypted with a previous key can not be read and is replaced.
+		store.log.Warn(fmt.Sprintf("replacing unreadable fallback store %v: %v", store.path, err))
+		entries = map[string]entry{}
+	}
+
+	savedAt := store.now().UTC()
+	for path, value := range values {
+		entries[storeKey(kind, path)] = entry{Value: value, SavedAt: savedAt}
+	}
+
+	if err = store.write(entries); err != nil {
+		store.log.Error(fmt.Sprintf("saving fallback store %v: %v", store.path, err))
+	}
+}
+
+// Fallback returns the last known good value of path when err is a technical error
+// (see utils.IsTechnicalError) and the value is not older than the max staleness.
+func (store *Store) Fallback(kind Kind, path string, err error) (Result, bool) {
+	if store == nil || !utils.IsTechnicalError(err) {
+		return Result{}, false
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	entries, readErr := store.read()
+	if readErr != nil {
+		store.log.Error(fmt.Sprintf("reading fallback store %v: %v", store.path, readErr))
+		return Result{}, false
+	}
+
+	stored, ok := entries[storeKey(kind, path)]
+	if !ok {
+		return Result{}, false
+	}
+	if age := store.now().Sub(stored.SavedAt); age > store.maxStaleness {
+		store.log.Warn(fmt.Sprintf("last known good value of %v is %v old, older than the max staleness %v", path, age.Round(time.Second), store.maxStaleness))
+		return Result{}, false
+	}
+
+	logging.RegisterSecret(store.log, stored.Value)
+	store.log.Warn(fmt.Sprintf("Password Safe unavailable, serving the last known good value of %v saved at %v: %v", path, stored.SavedAt.Format(time.RFC3339), err))
+	return Result{Value: stored.Value, Stale: true, SavedAt: stored.SavedAt}, true
+}
+
+// storeKey returns the key of path in the store.
+func storeKey(kind Kind, path string) string {
+	return string(kind) + ":" + path
+}
+
+// read decrypts the store, a missing file is an empty store.
+func (store *Store) read() (map[string]entry, error) {
+	content, err := os.ReadFile(store.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := store.aead.NonceSize()
+	if len(content) < nonceSize {
+		return nil, errors.New("fallback store is truncated")
+	}
+	plaintext, err := store.aead.Open(nil, content[:nonceSize], content[nonceSize:], additionalData)
+	if err != nil {
+		return nil, errors.New("fallback store can not be decrypted, wrong key or corrupted file")
+	}
+
+	entries := map[string]entry{}
+	if err = json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// write encrypts entries to a temporary file renamed over the store, so readers never see a partial file.
+func (store *Store) write(entries map[string]entry) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, store.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+	content := store.aead.Seal(nonce, nonce, plaintext, additionalData)
+
+	file, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if err = file.Chmod(0600); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if _, err = file.Write(content); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), store.path)
+}
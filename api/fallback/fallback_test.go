@@ -0,0 +1,124 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package fallback implements an encrypted on-disk store of last known good secret values.
+// Unit tests for fallback package.
+package fallback
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	"go.uber.org/zap"
+)
+
+var zapLogger = logging.NewZapLogger(zap.NewNop())
+
+var (
+	testKey       = bytes.Repeat([]byte{7}, 32)
+	technicalErr  = &utils.StatusCodeError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", HttpMethod: "GET"}
+	businessErr   = &utils.StatusCodeError{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: "not found"}
+	testStartTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func newTestStore(t *testing.T, path string, key []byte, now *time.Time) *Store {
+	store, err := NewStore(path, key, time.Hour, zapLogger)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestStoreFallback(t *testing.T) {
+	now := testStartTime
+	path := filepath.Join(t.TempDir(), "lkg.bin")
+	store := newTestStore(t, path, testKey, &now)
+
+	store.Save(KindSecret, map[string]string{"folder/title": "secret-value"})
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("fallback store permissions %v, expected 0600", info.Mode().Perm())
+	}
+	content, _ := os.ReadFile(path)
+	if bytes.Contains(content, []byte("secret-value")) || bytes.Contains(content, []byte("folder/title")) {
+		t.Error("Test case Failed, the store must be encrypted")
+	}
+
+	now = now.Add(30 * time.Minute)
+	result, ok := store.Fallback(KindSecret, "folder/title", technicalErr)
+	if !ok || result.Value != "secret-value" || !result.Stale || !result.SavedAt.Equal(testStartTime) {
+		t.Errorf("Test case Failed %+v %v", result, ok)
+	}
+
+	// Another process with the same key reads the store.
+	reopened := newTestStore(t, path, testKey, &now)
+	if result, ok = reopened.Fallback(KindSecret, "folder/title", technicalErr); !ok || result.Value != "secret-value" {
+		t.Errorf("Test case Failed %+v %v", result, ok)
+	}
+
+	if _, ok = store.Fallback(KindSecret, "folder/title", businessErr); ok {
+		t.Error("Test case Failed, business errors must not fall back")
+	}
+	if _, ok = store.Fallback(KindManagedAccount, "folder/title", technicalErr); ok {
+		t.Error("Test case Failed, kinds must not share paths")
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok = store.Fallback(KindSecret, "folder/title", technicalErr); ok {
+		t.Error("Test case Failed, stale values must not be served")
+	}
+}
+
+func TestStoreWrongKey(t *testing.T) {
+	now := testStartTime
+	path := filepath.Join(t.TempDir(), "lkg.bin")
+	newTestStore(t, path, testKey, &now).Save(KindSecret, map[string]string{"folder/title": "old"})
+
+	store := newTestStore(t, path, bytes.Repeat([]byte{8}, 32), &now)
+	if _, ok := store.Fallback(KindSecret, "folder/title", technicalErr); ok {
+		t.Error("Test case Failed, a store encrypted with another key must not be read")
+	}
+
+	// Saving with the new key replaces the unreadable store.
+	store.Save(KindSecret, map[string]string{"folder/other": "new"})
+	if result, ok := store.Fallback(KindSecret, "folder/other", technicalErr); !ok || result.Value != "new" {
+		t.Errorf("Test case Failed %+v %v", result, ok)
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	store.Save(KindSecret, map[string]string{"folder/title": "value"})
+	if _, ok := store.Fallback(KindSecret, "folder/title", technicalErr); ok {
+		t.Error("Test case Failed, a nil store serves nothing")
+	}
+}
+
+func TestNewStoreErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lkg.bin")
+	if _, err := NewStore(path, []byte("short"), time.Hour, zapLogger); err == nil {
+		t.Error("Test case Failed, an invalid key length must fail")
+	}
+	if _, err := NewStore(path, testKey, 0, zapLogger); err == nil {
+		t.Error("Test case Failed, a zero max staleness must fail")
+	}
+	if _, err := NewStore("", testKey, time.Hour, zapLogger); err == nil {
+		t.Error("Test case Failed, an empty path must fail")
+	}
+}
+
+func TestValues(t *testing.T) {
+	values := Values(map[string]Result{"a": {Value: "1"}, "b": {Value: "2", Stale: true}})
+	if len(values) != 2 || values["a"] != "1" || values["b"] != "2" {
+		t.Errorf("Test case Failed %v", values)
+	}
+}
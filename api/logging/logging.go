@@ -3,8 +3,10 @@
 package logging
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 
 	"github.com/go-logr/logr"
 	"go.uber.org/zap"
@@ -20,6 +22,7 @@ type Logger interface {
 
 // ZapLogger is a struct that implements the Logger interface using zap
 type ZapLogger struct {
+	redaction
 	logger *zap.Logger
 }
 
@@ -33,18 +36,52 @@ func (z *ZapLogger) Error(msg string) {
 	z.logger.Error(msg)
 }
 
-// Debug logs a message at error level
+// Debug logs a message at debug level
 func (z *ZapLogger) Debug(msg string) {
 	z.logger.Debug(msg)
 }
 
-// Warn logs a message at error level
+// Warn logs a message at warn level
 func (z *ZapLogger) Warn(msg string) {
 	z.logger.Warn(msg)
 }
 
+// DebugFields logs a message and fields at debug level
+func (z *ZapLogger) DebugFields(msg string, fields ...Field) {
+	z.logger.Debug(msg, z.zapFields(nil, fields)...)
+}
+
+// InfoFields logs a message and fields at info level
+func (z *ZapLogger) InfoFields(msg string, fields ...Field) {
+	z.logger.Info(msg, z.zapFields(nil, fields)...)
+}
+
+// WarnFields logs a message and fields at warn level
+func (z *ZapLogger) WarnFields(msg string, fields ...Field) {
+	z.logger.Warn(msg, z.zapFields(nil, fields)...)
+}
+
+// ErrorFields logs a message, error and fields at error level
+func (z *ZapLogger) ErrorFields(msg string, err error, fields ...Field) {
+	z.logger.Error(msg, z.zapFields(err, fields)...)
+}
+
+// zapFields converts redacted fields to zap fields.
+func (z *ZapLogger) zapFields(err error, fields []Field) []zap.Field {
+	fields = z.apply(fields)
+	zapFields := make([]zap.Field, 0, len(fields)+1)
+	for _, field := range fields {
+		zapFields = append(zapFields, zap.Any(field.Key, field.Value))
+	}
+	if err != nil {
+		zapFields = append(zapFields, zap.Error(z.applyError(err)))
+	}
+	return zapFields
+}
+
 // logr.logger
 type LogrLogger struct {
+	redaction
 	logger *logr.Logger
 }
 
@@ -55,19 +92,52 @@ func (r *LogrLogger) Info(msg string) {
 
 // Error logs a message at error level
 func (r *LogrLogger) Error(msg string) {
-	r.logger.Error(fmt.Errorf("an error"), msg)
+	r.logger.Error(nil, msg)
 }
 
+// Debug logs a message at verbosity level 1, logr's conventional debug level
 func (r *LogrLogger) Debug(msg string) {
-	r.logger.Info(msg)
+	r.logger.V(1).Info(msg)
 }
 
+// Warn logs a message at info level, logr has no warn level so the entry is tagged with level=warn
 func (r *LogrLogger) Warn(msg string) {
-	r.logger.Info(msg)
+	r.logger.Info(msg, "level", "warn")
+}
+
+// DebugFields logs a message and fields at verbosity level 1
+func (r *LogrLogger) DebugFields(msg string, fields ...Field) {
+	r.logger.V(1).Info(msg, r.keysAndValues(fields)...)
+}
+
+// InfoFields logs a message and fields at info level
+func (r *LogrLogger) InfoFields(msg string, fields ...Field) {
+	r.logger.Info(msg, r.keysAndValues(fields)...)
+}
+
+// WarnFields logs a message and fields at info level tagged with level=warn
+func (r *LogrLogger) WarnFields(msg string, fields ...Field) {
+	r.logger.Info(msg, append([]interface{}{"level", "warn"}, r.keysAndValues(fields)...)...)
+}
+
+// ErrorFields logs a message, error and fields at error level
+func (r *LogrLogger) ErrorFields(msg string, err error, fields ...Field) {
+	r.logger.Error(r.applyError(err), msg, r.keysAndValues(fields)...)
+}
+
+// keysAndValues converts redacted fields to logr key/value pairs.
+func (r *LogrLogger) keysAndValues(fields []Field) []interface{} {
+	fields = r.apply(fields)
+	keysAndValues := make([]interface{}, 0, len(fields)*2)
+	for _, field := range fields {
+		keysAndValues = append(keysAndValues, field.Key, field.Value)
+	}
+	return keysAndValues
 }
 
 // log.logger
 type LogLogger struct {
+	redaction
 	logger *log.Logger
 }
 
@@ -92,13 +162,92 @@ func (l *LogLogger) Debug(msg string) {
 	l.logger.Println(msg)
 }
 
-// Warn logs a message at debug level
+// Warn logs a message at warn level
 func (l *LogLogger) Warn(msg string) {
 	prefix := fmt.Sprintf("%v :", "Warn")
 	l.logger.SetPrefix(prefix)
 	l.logger.Println(msg)
 }
 
+// DebugFields logs a message and fields at debug level
+func (l *LogLogger) DebugFields(msg string, fields ...Field) {
+	l.Debug(formatMessage(msg, nil, l.apply(fields)))
+}
+
+// InfoFields logs a message and fields at info level
+func (l *LogLogger) InfoFields(msg string, fields ...Field) {
+	l.Info(formatMessage(msg, nil, l.apply(fields)))
+}
+
+// WarnFields logs a message and fields at warn level
+func (l *LogLogger) WarnFields(msg string, fields ...Field) {
+	l.Warn(formatMessage(msg, nil, l.apply(fields)))
+}
+
+// ErrorFields logs a message, error and fields at error level
+func (l *LogLogger) ErrorFields(msg string, err error, fields ...Field) {
+	l.Error(formatMessage(msg, l.applyError(err), l.apply(fields)))
+}
+
+// SlogLogger is a struct that implements the Logger interface using log/slog
+type SlogLogger struct {
+	redaction
+	logger *slog.Logger
+}
+
+// Info logs a message at info level
+func (s *SlogLogger) Info(msg string) {
+	s.logger.Info(msg)
+}
+
+// Error logs a message at error level
+func (s *SlogLogger) Error(msg string) {
+	s.logger.Error(msg)
+}
+
+// Debug logs a message at debug level
+func (s *SlogLogger) Debug(msg string) {
+	s.logger.Debug(msg)
+}
+
+// Warn logs a message at warn level
+func (s *SlogLogger) Warn(msg string) {
+	s.logger.Warn(msg)
+}
+
+// DebugFields logs a message and fields at debug level
+func (s *SlogLogger) DebugFields(msg string, fields ...Field) {
+	s.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, s.attrs(nil, fields)...)
+}
+
+// InfoFields logs a message and fields at info level
+func (s *SlogLogger) InfoFields(msg string, fields ...Field) {
+	s.logger.LogAttrs(context.Background(), slog.LevelInfo, msg, s.attrs(nil, fields)...)
+}
+
+// WarnFields logs a message and fields at warn level
+func (s *SlogLogger) WarnFields(msg string, fields ...Field) {
+	s.logger.LogAttrs(context.Background(), slog.LevelWarn, msg, s.attrs(nil, fields)...)
+}
+
+// ErrorFields logs a message, error and fields at error level
+func (s *SlogLogger) ErrorFields(msg string, err error, fields ...Field) {
+	s.logger.LogAttrs(context.Background(), slog.LevelError, msg, s.attrs(err, fields)...)
+}
+
+// attrs converts redacted fields to slog attributes.
+func (s *SlogLogger) attrs(err error, fields []Field) []slog.Attr {
+	fields = s.apply(fields)
+	attrs := make([]slog.Attr, 0, len(fields)+1)
+	for _, field := range fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", s.applyError(err)))
+	}
+	return attrs
+}
+
 // NewZapLogger creates a new ZapLogger with the given zap.Logger
 func NewZapLogger(logger *zap.Logger) *ZapLogger {
 	return &ZapLogger{logger: logger}
@@ -113,3 +262,8 @@ func NewLogrLogger(logger *logr.Logger) *LogrLogger {
 func NewLogLogger(logger *log.Logger) *LogLogger {
 	return &LogLogger{logger: logger}
 }
+
+// NewSlogLogger creates a new SlogLogger with the given slog.Logger
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package logging abstraction.
+package logging
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedactionMask replaces every redacted value.
+const RedactionMask = "****"
+
+// requestIDPathRE matches the credential-bearing path segment in
+// /Credentials/<requestId> and /Requests/<requestId>(/checkin). The capture
+// preserves the original case of the resource name; [^/?#]+ matches a single
+// path segment regardless of how it is percent-encoded.
+var requestIDPathRE = regexp.MustCompile(`(?i)(/(?:credentials|requests))/[^/?#\s"]+`)
+
+// sensitivePatterns match credentials that can be recognised without being
+// registered: the capture group is kept, the rest of the match is masked.
+var sensitivePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`(?i)(PS-Auth\s+key=)[^;\s"]+`),
+	regexp.MustCompile(`(?i)(pwd=)\[[^\]]*\]`),
+	regexp.MustCompile(`(?i)(client_secret["']?\s*[=:]\s*["']?)[^&\s"',;]+`),
+	regexp.MustCompile(`(?i)(access_token["']?\s*[=:]\s*["']?)[^&\s"',;]+`),
+	regexp.MustCompile(`(?s)(-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----).*?-----END [A-Z0-9 ]*PRIVATE KEY-----`),
+}
+
+// RedactRequestIDs masks the request ID in /Credentials/<requestId> and
+// /Requests/<requestId> paths. The request ID is the short-lived token that,
+// presented to GET /Credentials/{requestId}, returns a plaintext managed-account
+// password, so it must never appear in clear text in log output.
+func RedactRequestIDs(text string) string {
+	return requestIDPathRE.ReplaceAllString(text, "$1/"+RedactionMask)
+}
+
+// SecretRegistrar is implemented by loggers that scrub registered secret values.
+type SecretRegistrar interface {
+	Register(values ...string)
+}
+
+// RegisterSecret registers values with logger when it scrubs secret values,
+// otherwise it does nothing. Retrieval flows call it for every value they fetch.
+func RegisterSecret(logger Logger, values ...string) {
+	if registrar, ok := logger.(SecretRegistrar); ok {
+		registrar.Register(values...)
+	}
+}
+
+// RedactingLogger wraps a Logger and scrubs registered secret values, bearer
+// tokens, API keys, client secrets, access tokens and request IDs from every
+// message and field before forwarding it.
+type RedactingLogger struct {
+	logger     StructuredLogger
+	mu         sync.RWMutex
+	secrets    []string          // longest first
+	registered map[string]uint64 // value to the sequence of its last registration
+	sequence   uint64
+	maxSecrets int
+}
+
+// DefaultMaxRegisteredSecrets bounds the values a RedactingLogger scrubs, the least
+// recently registered value is dropped first.
+const DefaultMaxRegisteredSecrets = 10000
+
+// NewRedactingLogger creates a RedactingLogger forwarding to logger.
+func NewRedactingLogger(logger Logger) *RedactingLogger {
+	return &RedactingLogger{logger: Structured(logger), registered: map[string]uint64{}, maxSecrets: DefaultMaxRegisteredSecrets}
+}
+
+// SetMaxSecrets bounds the registered values to maxSecrets, dropping the least recently
+// registered values above it. Long-running processes registering a value per retrieval
+// keep the memory and the cost of every log entry bounded.
+func (r *RedactingLogger) SetMaxSecrets(maxSecrets int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.maxSecrets = maxSecrets
+	r.evict()
+}
+
+// Register adds secret values to scrub from every subsequent log entry.
+// Empty values and values made only of mask characters are ignored, registering
+// a value again marks it as recently registered.
+func (r *RedactingLogger) Register(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.registered == nil {
+		r.registered = map[string]uint64{}
+	}
+	for _, value := range values {
+		if strings.Trim(value, "*") == "" {
+			continue
+		}
+		r.sequence++
+		if _, ok := r.registered[value]; !ok {
+			// replace longer values first so a secret containing another one is fully masked.
+			i := sort.Search(len(r.secrets), func(i int) bool { return len(r.secrets[i]) < len(value) })
+			r.secrets = slices.Insert(r.secrets, i, value)
+		}
+		r.registered[value] = r.sequence
+	}
+	r.evict()
+}
+
+// Unregister stops scrubbing values, e.g. once the lease of a credential ends.
+func (r *RedactingLogger) Unregister(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, value := range values {
+		r.remove(value)
+	}
+}
+
+// evict drops the least recently registered values above maxSecrets, callers hold the lock.
+func (r *RedactingLogger) evict() {
+	for r.maxSecrets > 0 && len(r.secrets) > r.maxSecrets {
+		oldest, oldestSequence := "", uint64(0)
+		for value, sequence := range r.registered {
+			if oldest == "" || sequence < oldestSequence {
+				oldest, oldestSequence = value, sequence
+			}
+		}
+		r.remove(oldest)
+	}
+}
+
+// remove drops value, callers hold the lock.
+func (r *RedactingLogger) remove(value string) {
+	if _, ok := r.registered[value]; !ok {
+		return
+	}
+	delete(r.registered, value)
+	r.secrets = slices.DeleteFunc(r.secrets, func(secret string) bool { return secret == value })
+}
+
+// Redact returns text with all registered and recognised secrets masked.
+func (r *RedactingLogger) Redact(text string) string {
+	text = r.maskRegistered(text)
+
+	for _, pattern := range sensitivePatterns {
+		text = pattern.ReplaceAllString(text, "${1}"+RedactionMask)
+	}
+
+	// the patterns add mask characters, which may complete a registered value again.
+	return r.maskRegistered(RedactRequestIDs(text))
+}
+
+// maskRegistered replaces registered secrets until none is left. Masking can
+// create a new occurrence from surrounding text ("aa*" with secret "a*"), but
+// every replacement removes at least one unmasked character so the loop terminates.
+func (r *RedactingLogger) maskRegistered(text string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for replaced := true; replaced; {
+		replaced = false
+		for _, secret := range r.secrets {
+			if strings.Contains(text, secret) {
+				text = strings.ReplaceAll(text, secret, RedactionMask)
+				replaced = true
+			}
+		}
+	}
+	return text
+}
+
+// redactFields returns a copy of fields with every value that may carry text redacted.
+func (r *RedactingLogger) redactFields(fields []Field) []Field {
+	redacted := make([]Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = Field{Key: field.Key, Value: r.redactValue(field.Value)}
+	}
+	return redacted
+}
+
+// redactValue keeps numbers, booleans and durations and redacts the text form of anything else.
+func (r *RedactingLogger) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64, time.Duration:
+		return v
+	case string:
+		return r.Redact(v)
+	case error:
+		return r.redactError(v)
+	default:
+		return r.Redact(fmt.Sprintf("%v", v))
+	}
+}
+
+// redactError returns err with its message redacted.
+func (r *RedactingLogger) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return redactedError(r.Redact(err.Error()))
+}
+
+// Info logs a redacted message at info level
+func (r *RedactingLogger) Info(msg string) {
+	r.logger.Info(r.Redact(msg))
+}
+
+// Error logs a redacted message at error level
+func (r *RedactingLogger) Error(msg string) {
+	r.logger.Error(r.Redact(msg))
+}
+
+// Debug logs a redacted message at debug level
+func (r *RedactingLogger) Debug(msg string) {
+	r.logger.Debug(r.Redact(msg))
+}
+
+// Warn logs a redacted message at warn level
+func (r *RedactingLogger) Warn(msg string) {
+	r.logger.Warn(r.Redact(msg))
+}
+
+// DebugFields logs a redacted message and fields at debug level
+func (r *RedactingLogger) DebugFields(msg string, fields ...Field) {
+	r.logger.DebugFields(r.Redact(msg), r.redactFields(fields)...)
+}
+
+// InfoFields logs a redacted message and fields at info level
+func (r *RedactingLogger) InfoFields(msg string, fields ...Field) {
+	r.logger.InfoFields(r.Redact(msg), r.redactFields(fields)...)
+}
+
+// WarnFields logs a redacted message and fields at warn level
+func (r *RedactingLogger) WarnFields(msg string, fields ...Field) {
+	r.logger.WarnFields(r.Redact(msg), r.redactFields(fields)...)
+}
+
+// ErrorFields logs a redacted message, error and fields at error level
+func (r *RedactingLogger) ErrorFields(msg string, err error, fields ...Field) {
+	r.logger.ErrorFields(r.Redact(msg), r.redactError(err), r.redactFields(fields)...)
+}
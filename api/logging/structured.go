@@ -0,0 +1,173 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package logging abstraction.
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Field is a key/value pair attached to a structured log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a string field.
+func String(key string, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int creates an int field.
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool creates a bool field.
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration creates a time.Duration field.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Any creates a field holding an arbitrary value.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Redactor rewrites the value of a field before it is emitted, e.g. to mask
+// credentials. It is applied to every field of every structured log entry.
+type Redactor func(key string, value interface{}) interface{}
+
+// StructuredLogger is a leveled logger accepting key/value fields and error values.
+// It embeds Logger so structured loggers can be used anywhere a Logger is expected.
+type StructuredLogger interface {
+	Logger
+	DebugFields(msg string, fields ...Field)
+	InfoFields(msg string, fields ...Field)
+	WarnFields(msg string, fields ...Field)
+	ErrorFields(msg string, err error, fields ...Field)
+}
+
+// redaction holds the redaction hook shared by the logger adapters.
+type redaction struct {
+	mu       sync.RWMutex
+	redactor Redactor
+}
+
+// SetRedactor sets the hook applied to every field before it is emitted.
+func (r *redaction) SetRedactor(redactor Redactor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.redactor = redactor
+}
+
+// apply returns a copy of fields with the redactor applied to each value.
+func (r *redaction) apply(fields []Field) []Field {
+	r.mu.RLock()
+	redactor := r.redactor
+	r.mu.RUnlock()
+
+	if redactor == nil || len(fields) == 0 {
+		return fields
+	}
+
+	redacted := make([]Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = Field{Key: field.Key, Value: redactor(field.Key, field.Value)}
+	}
+	return redacted
+}
+
+// applyError runs the redactor on an error value, keeping it an error when possible.
+func (r *redaction) applyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	redactor := r.redactor
+	r.mu.RUnlock()
+
+	if redactor == nil {
+		return err
+	}
+
+	switch value := redactor("error", err).(type) {
+	case error:
+		return value
+	case string:
+		return redactedError(value)
+	default:
+		return redactedError(fmt.Sprintf("%v", value))
+	}
+}
+
+// redactedError carries the message of an error after redaction.
+type redactedError string
+
+func (e redactedError) Error() string {
+	return string(e)
+}
+
+// FormatFields renders fields as space separated key=value pairs.
+func FormatFields(fields []Field) string {
+	var builder strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			builder.WriteString(" ")
+		}
+		fmt.Fprintf(&builder, "%s=%v", field.Key, field.Value)
+	}
+	return builder.String()
+}
+
+// formatMessage appends the rendered fields (and error, if any) to msg.
+func formatMessage(msg string, err error, fields []Field) string {
+	if err != nil {
+		fields = append(fields, Field{Key: "error", Value: err.Error()})
+	}
+	if len(fields) == 0 {
+		return msg
+	}
+	return msg + " " + FormatFields(fields)
+}
+
+// plainStructuredLogger adapts a Logger without native field support.
+type plainStructuredLogger struct {
+	Logger
+}
+
+// DebugFields logs a message and fields at debug level.
+func (p plainStructuredLogger) DebugFields(msg string, fields ...Field) {
+	p.Debug(formatMessage(msg, nil, fields))
+}
+
+// InfoFields logs a message and fields at info level.
+func (p plainStructuredLogger) InfoFields(msg string, fields ...Field) {
+	p.Info(formatMessage(msg, nil, fields))
+}
+
+// WarnFields logs a message and fields at warn level.
+func (p plainStructuredLogger) WarnFields(msg string, fields ...Field) {
+	p.Warn(formatMessage(msg, nil, fields))
+}
+
+// ErrorFields logs a message, error and fields at error level.
+func (p plainStructuredLogger) ErrorFields(msg string, err error, fields ...Field) {
+	p.Error(formatMessage(msg, err, fields))
+}
+
+// Structured returns logger as a StructuredLogger. Loggers that do not support
+// fields natively get them appended to the message as key=value pairs.
+func Structured(logger Logger) StructuredLogger {
+	if structuredLogger, ok := logger.(StructuredLogger); ok {
+		return structuredLogger
+	}
+	return plainStructuredLogger{Logger: logger}
+}
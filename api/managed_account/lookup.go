@@ -0,0 +1,295 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package managed_accounts implements functions to retrieve managed accounts
+package managed_accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// Prefixes of the managed account paths that do not use system and account names.
+const (
+	lookupById          = "id"
+	lookupByDomain      = "domain"
+	lookupByUpn         = "upn"
+	lookupByInstance    = "instance"
+	lookupByApplication = "application"
+)
+
+// managedAccountLookup selects a managed account, by system and account names when kind is empty.
+type managedAccountLookup struct {
+	kind              string
+	accountId         int
+	applicationId     int
+	systemName        string
+	accountName       string
+	domainName        string
+	userPrincipalName string
+	instanceName      string
+}
+
+// lookupKind returns the lookup prefix of secretPath, empty for system/account paths.
+func lookupKind(secretPath string) string {
+	prefix, _, found := strings.Cut(strings.TrimSpace(secretPath), ":")
+	if !found {
+		return ""
+	}
+	switch prefix {
+	case lookupById, lookupByDomain, lookupByUpn, lookupByInstance, lookupByApplication:
+		return prefix
+	}
+	return ""
+}
+
+// parseManagedAccountPath parses a managed account path:
+//
+//	system/account
+//	id:<account id>
+//	domain:<domain>\<account>
+//	upn:<user principal name>
+//	instance:<system>/<instance>/<account>
+//	application:<application id>[/<account>]
+//
+// where / is separator.
+func parseManagedAccountPath(secretPath string, separator string) (managedAccountLookup, error) {
+	kind := lookupKind(secretPath)
+	_, value, _ := strings.Cut(strings.TrimSpace(secretPath), ":")
+	lookup := managedAccountLookup{kind: kind}
+
+	switch kind {
+	case lookupById:
+		accountId, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || accountId <= 0 {
+			return lookup, fmt.Errorf("invalid managed account path %v, expected id:<account id>", secretPath)
+		}
+		lookup.accountId = accountId
+
+	case lookupByDomain:
+		domainName, accountName, found := strings.Cut(value, `\`)
+		lookup.domainName, lookup.accountName = strings.TrimSpace(domainName), strings.TrimSpace(accountName)
+		if !found || lookup.domainName == "" || lookup.accountName == "" {
+			return lookup, fmt.Errorf(`invalid managed account path %v, expected domain:<domain>\<account>`, secretPath)
+		}
+
+	case lookupByUpn:
+		lookup.userPrincipalName = strings.TrimSpace(value)
+		if !strings.Contains(lookup.userPrincipalName, "@") {
+			return lookup, fmt.Errorf("invalid managed account path %v, expected upn:<user principal name>", secretPath)
+		}
+
+	case lookupByInstance:
+		parts := strings.Split(value, separator)
+		if len(parts) != 3 || slices.ContainsFunc(parts, isBlank) {
+			return lookup, fmt.Errorf("invalid managed account path %v, expected instance:<system>%v<instance>%v<account>", secretPath, separator, separator)
+		}
+		lookup.systemName, lookup.instanceName, lookup.accountName = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2])
+
+	case lookupByApplication:
+		applicationId, accountName, found := strings.Cut(value, separator)
+		id, err := strconv.Atoi(strings.TrimSpace(applicationId))
+		lookup.applicationId, lookup.accountName = id, strings.TrimSpace(accountName)
+		if err != nil || id <= 0 || (found && lookup.accountName == "") {
+			return lookup, fmt.Errorf("invalid managed account path %v, expected application:<application id>[%v<account>]", secretPath, separator)
+		}
+
+	default:
+		parts := strings.Split(secretPath, separator)
+		if len(parts) != 2 || slices.ContainsFunc(parts, isBlank) {
+			return lookup, fmt.Errorf("invalid managed account path %v, expected system%vaccount", secretPath, separator)
+		}
+		lookup.systemName, lookup.accountName = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	}
+
+	return lookup, nil
+}
+
+// isBlank returns true when part is empty or white space.
+func isBlank(part string) bool {
+	return strings.TrimSpace(part) == ""
+}
+
+// String returns the lookup as a path with / as separator.
+func (lookup managedAccountLookup) String() string {
+	switch lookup.kind {
+	case lookupById:
+		return fmt.Sprintf("id:%v", lookup.accountId)
+	case lookupByDomain:
+		return fmt.Sprintf(`domain:%v\%v`, lookup.domainName, lookup.accountName)
+	case lookupByUpn:
+		return "upn:" + lookup.userPrincipalName
+	case lookupByInstance:
+		return fmt.Sprintf("instance:%v/%v/%v", lookup.systemName, lookup.instanceName, lookup.accountName)
+	case lookupByApplication:
+		if lookup.accountName == "" {
+			return fmt.Sprintf("application:%v", lookup.applicationId)
+		}
+		return fmt.Sprintf("application:%v/%v", lookup.applicationId, lookup.accountName)
+	}
+	return lookup.systemName + "/" + lookup.accountName
+}
+
+// key identifies the account selected by the lookup, whatever the separator.
+func (lookup managedAccountLookup) key() string {
+	return strings.Join([]string{lookup.kind, strconv.Itoa(lookup.accountId), strconv.Itoa(lookup.applicationId),
+		lookup.systemName, lookup.accountName, lookup.domainName, lookup.userPrincipalName, lookup.instanceName}, "\x00")
+}
+
+// matches returns true when managedAccount is the account selected by the lookup.
+func (lookup managedAccountLookup) matches(managedAccount entities.ManagedAccount) bool {
+	switch lookup.kind {
+	case lookupById:
+		return managedAccount.AccountId == lookup.accountId
+	case lookupByDomain:
+		return strings.EqualFold(managedAccount.DomainName, lookup.domainName) && strings.EqualFold(managedAccount.AccountName, lookup.accountName)
+	case lookupByUpn:
+		return strings.EqualFold(managedAccount.UserPrincipalName, lookup.userPrincipalName)
+	case lookupByInstance:
+		return strings.EqualFold(managedAccount.SystemName, lookup.systemName) && strings.EqualFold(managedAccount.InstanceName, lookup.instanceName) &&
+			strings.EqualFold(managedAccount.AccountName, lookup.accountName)
+	case lookupByApplication:
+		return managedAccount.ApplicationID == lookup.applicationId && (lookup.accountName == "" || strings.EqualFold(managedAccount.AccountName, lookup.accountName))
+	}
+	return strings.EqualFold(managedAccount.SystemName, lookup.systemName) && strings.EqualFold(managedAccount.AccountName, lookup.accountName)
+}
+
+// validatePaths validates system/account paths with utils.ValidatePaths and parses the
+// lookup paths, invalid lookup paths are logged and skipped.
+func (managedAccountObj *ManagedAccountstObj) validatePaths(secretPaths []string, separator string) []string {
+	accountPaths := []string{}
+	lookupPaths := []string{}
+	for _, secretPath := range secretPaths {
+		if lookupKind(secretPath) == "" {
+			accountPaths = append(accountPaths, secretPath)
+			continue
+		}
+		if _, err := parseManagedAccountPath(secretPath, separator); err != nil {
+			managedAccountObj.log.Error(err.Error())
+			continue
+		}
+		lookupPaths = append(lookupPaths, secretPath)
+	}
+	return append(lookupPaths, utils.ValidatePaths(accountPaths, true, separator, managedAccountObj.log)...)
+}
+
+// lookupManagedAccount returns the managed account selected by lookup.
+func (managedAccountObj *ManagedAccountstObj) lookupManagedAccount(lookup managedAccountLookup) (entities.ManagedAccount, error) {
+	switch lookup.kind {
+	case lookupById:
+		return managedAccountObj.ManagedAccountGetById(lookup.accountId)
+	case lookupByDomain:
+		return managedAccountObj.ManagedAccountGetByDomain(lookup.domainName, lookup.accountName)
+	case lookupByUpn:
+		return managedAccountObj.ManagedAccountGetByUserPrincipalName(lookup.userPrincipalName)
+	case lookupByInstance:
+		return managedAccountObj.ManagedAccountGetByInstance(lookup.systemName, lookup.instanceName, lookup.accountName)
+	case lookupByApplication:
+		return managedAccountObj.ManagedAccountGetByApplication(lookup.applicationId, lookup.accountName)
+	}
+
+	v := url.Values{}
+	v.Add("systemName", lookup.systemName)
+	v.Add("accountName", lookup.accountName)
+
+	ManagedAccountGetUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("ManagedAccounts").String() + "?" + v.Encode()
+	return managedAccountObj.ManagedAccountGet(lookup.systemName, lookup.accountName, ManagedAccountGetUrl)
+}
+
+// ManagedAccountGetById calls Password Safe API ManagedAccounts/<id> endpoint.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountGetById(accountId int) (entities.ManagedAccount, error) {
+	ManagedAccountGetUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("ManagedAccounts", strconv.Itoa(accountId)).String()
+	messageLog := fmt.Sprintf("%v %v", "GET", ManagedAccountGetUrl)
+	managedAccountObj.log.Debug(messageLog)
+
+	response, err := managedAccountObj.sendRequestAndGetSingleString("GET", ManagedAccountGetUrl, constants.ManagedAccountGet, bytes.Buffer{})
+	if err != nil {
+		return entities.ManagedAccount{}, err
+	}
+
+	// ManagedAccounts/<id> returns the admin model of the account.
+	var managedAccountDetails entities.ManagedAccountDetailsResponse
+	if err = json.Unmarshal([]byte(response), &managedAccountDetails); err != nil {
+		managedAccountObj.log.Error(err.Error())
+		return entities.ManagedAccount{}, err
+	}
+	return entities.ManagedAccount{
+		SystemId:               managedAccountDetails.ManagedSystemID,
+		AccountId:              managedAccountDetails.ManagedAccountID,
+		AccountName:            managedAccountDetails.AccountName,
+		DomainName:             managedAccountDetails.DomainName,
+		UserPrincipalName:      managedAccountDetails.UserPrincipalName,
+		AccountDescription:     managedAccountDetails.Description,
+		DefaultReleaseDuration: managedAccountDetails.DefaultReleaseDuration,
+		MaximumReleaseDuration: managedAccountDetails.MaximumReleaseDuration,
+		LastChangeDate:         managedAccountDetails.LastChangeDate,
+		NextChangeDate:         managedAccountDetails.NextChangeDate,
+		IsChanging:             managedAccountDetails.IsChanging,
+		ChangeState:            managedAccountDetails.ChangeState,
+	}, nil
+}
+
+// ManagedAccountGetByDomain returns the domain linked account domainName\accountName.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountGetByDomain(domainName string, accountName string) (entities.ManagedAccount, error) {
+	v := url.Values{}
+	v.Add("accountName", accountName)
+	v.Add("type", "domainlinked")
+	return managedAccountObj.findManagedAccount(v, 0, managedAccountLookup{kind: lookupByDomain, domainName: domainName, accountName: accountName})
+}
+
+// maxUpnLookupAccounts bounds the accounts listed by ManagedAccountGetByUserPrincipalName.
+var maxUpnLookupAccounts = 10000
+
+// ManagedAccountGetByUserPrincipalName returns the account whose user principal name is userPrincipalName.
+// ManagedAccounts cannot be filtered by user principal name, so the requestable accounts are listed
+// and matched here; the lookup fails when more than maxUpnLookupAccounts accounts are requestable,
+// use an id: or domain: path then.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountGetByUserPrincipalName(userPrincipalName string) (entities.ManagedAccount, error) {
+	return managedAccountObj.findManagedAccount(url.Values{}, maxUpnLookupAccounts, managedAccountLookup{kind: lookupByUpn, userPrincipalName: userPrincipalName})
+}
+
+// ManagedAccountGetByInstance returns the account accountName of the database instance instanceName of systemName.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountGetByInstance(systemName string, instanceName string, accountName string) (entities.ManagedAccount, error) {
+	v := url.Values{}
+	v.Add("systemName", systemName)
+	v.Add("type", "database")
+	return managedAccountObj.findManagedAccount(v, 0, managedAccountLookup{kind: lookupByInstance, systemName: systemName, instanceName: instanceName, accountName: accountName})
+}
+
+// ManagedAccountGetByApplication returns the account of the application applicationId, accountName
+// selects the account when the application has several, it may be empty otherwise.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountGetByApplication(applicationId int, accountName string) (entities.ManagedAccount, error) {
+	v := url.Values{}
+	v.Add("type", "application")
+	return managedAccountObj.findManagedAccount(v, 0, managedAccountLookup{kind: lookupByApplication, applicationId: applicationId, accountName: accountName})
+}
+
+// findManagedAccount lists the managed accounts filtered by query, at most maxAccounts when it is not 0,
+// and returns the single one matching lookup.
+func (managedAccountObj *ManagedAccountstObj) findManagedAccount(query url.Values, maxAccounts int, lookup managedAccountLookup) (entities.ManagedAccount, error) {
+	managedAccounts, err := managedAccountObj.listManagedAccounts(query, maxAccounts)
+	if err != nil {
+		return entities.ManagedAccount{}, err
+	}
+
+	var found []entities.ManagedAccount
+	for _, managedAccount := range managedAccounts {
+		if lookup.matches(managedAccount) {
+			found = append(found, managedAccount)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return entities.ManagedAccount{}, utils.NewNotFoundError("managed account %v was not found", lookup)
+	case 1:
+		return found[0], nil
+	}
+	return entities.ManagedAccount{}, fmt.Errorf("managed account %v is ambiguous, %v accounts match", lookup, len(found))
+}
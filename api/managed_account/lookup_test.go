@@ -0,0 +1,137 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package managed_accounts implements functions to retrieve managed accounts
+// Unit tests for managed_accounts package.
+package managed_accounts
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+)
+
+func TestParseManagedAccountPath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected managedAccountLookup
+	}{
+		{"system01/account01", managedAccountLookup{systemName: "system01", accountName: "account01"}},
+		{"id:42", managedAccountLookup{kind: lookupById, accountId: 42}},
+		{`domain:CORP\svc_app`, managedAccountLookup{kind: lookupByDomain, domainName: "CORP", accountName: "svc_app"}},
+		{"upn:svc_app@corp.example.com", managedAccountLookup{kind: lookupByUpn, userPrincipalName: "svc_app@corp.example.com"}},
+		{"instance:sql01/MSSQLSERVER/sa", managedAccountLookup{kind: lookupByInstance, systemName: "sql01", instanceName: "MSSQLSERVER", accountName: "sa"}},
+		{"application:7", managedAccountLookup{kind: lookupByApplication, applicationId: 7}},
+		{"application:7/app_user", managedAccountLookup{kind: lookupByApplication, applicationId: 7, accountName: "app_user"}},
+	}
+	for _, testCase := range testCases {
+		lookup, err := parseManagedAccountPath(testCase.path, "/")
+		if err != nil || lookup != testCase.expected {
+			t.Errorf("Test case Failed for %v: %+v, %v", testCase.path, lookup, err)
+		}
+	}
+
+	invalidPaths := []string{"id:abc", "id:0", `domain:CORP`, `domain:\svc`, "upn:svc_app", "instance:sql01/sa", "application:x", "application:7/", "system01"}
+	for _, path := range invalidPaths {
+		if _, err := parseManagedAccountPath(path, "/"); err == nil {
+			t.Errorf("Test case Failed, %v must be invalid", path)
+		}
+	}
+}
+
+func TestManageAccountFlowLookups(t *testing.T) {
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var requests [][2]int
+	var requestsLock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ManagedAccounts/42":
+			// ManagedAccounts/<id> returns the admin model.
+			_, _ = w.Write([]byte(`{"ManagedAccountID":42,"ManagedSystemID":1,"AccountName":"account42","DomainName":"","UserPrincipalName":"",
+				"DefaultReleaseDuration":120,"MaximumReleaseDuration":525600,"IsChanging":false,"ChangeState":0}`))
+		case r.URL.Path == "/ManagedAccounts":
+			query := r.URL.Query()
+			switch {
+			case query.Get("type") == "domainlinked" && query.Get("accountName") == "svc_app":
+				_, _ = w.Write([]byte(`[{"SystemId":2,"AccountId":50,"AccountName":"svc_app","DomainName":"OTHER"},
+					{"SystemId":2,"AccountId":51,"AccountName":"svc_app","DomainName":"corp"}]`))
+			case query.Get("type") == "database" && query.Get("systemName") == "sql01":
+				_, _ = w.Write([]byte(`[{"SystemId":3,"SystemName":"sql01","AccountId":60,"AccountName":"sa","InstanceName":"MSSQLSERVER"}]`))
+			case query.Get("type") == "application":
+				_, _ = w.Write([]byte(`[{"SystemId":4,"AccountId":70,"AccountName":"app_user","ApplicationID":7},
+					{"SystemId":4,"AccountId":71,"AccountName":"app_admin","ApplicationID":7}]`))
+			case query.Get("type") == "":
+				_, _ = w.Write([]byte(`[{"SystemId":5,"AccountId":80,"AccountName":"svc_cloud","UserPrincipalName":"svc_cloud@corp.example.com"}]`))
+			default:
+				http.NotFound(w, r)
+			}
+		case r.URL.Path == "/Requests":
+			var request struct{ SystemID, AccountID int }
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			requestsLock.Lock()
+			requests = append(requests, [2]int{request.SystemID, request.AccountID})
+			requestsLock.Unlock()
+			_, _ = w.Write([]byte(`124`))
+		case r.URL.Path == "/Credentials/124":
+			_, _ = w.Write([]byte(`"fake_credential"`))
+		case strings.HasSuffix(r.URL.Path, "/checkin"):
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	paths := []string{"id:42", `domain:CORP\svc_app`, "upn:svc_cloud@corp.example.com", "instance:sql01/MSSQLSERVER/sa", "application:7/app_user"}
+	secrets, err := managedAccountObj.GetSecrets(paths, "/")
+	if err != nil || len(secrets) != len(paths) {
+		t.Fatalf("Test case Failed %v, %v", secrets, err)
+	}
+	for _, path := range paths {
+		if secrets[path] != "fake_credential" {
+			t.Errorf("Test case Failed for %v: %v", path, secrets[path])
+		}
+	}
+
+	// The request of id:42 is created for the system and account of the admin model.
+	if !slices.Contains(requests, [2]int{1, 42}) {
+		t.Errorf("Test case Failed, requests %v", requests)
+	}
+
+	managedAccount, err := managedAccountObj.ManagedAccountGetByDomain("corp", "svc_app")
+	if err != nil || managedAccount.AccountId != 51 {
+		t.Errorf("Test case Failed %+v, %v", managedAccount, err)
+	}
+
+	// Application 7 has two accounts.
+	if _, err = managedAccountObj.ManagedAccountGetByApplication(7, ""); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if _, err = managedAccountObj.ManagedAccountGetByUserPrincipalName("unknown@corp.example.com"); err == nil || !strings.Contains(err.Error(), "was not found") {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	// The user principal name lookup lists at most maxUpnLookupAccounts accounts.
+	pageSize, maxAccounts := managedAccountsPageSize, maxUpnLookupAccounts
+	managedAccountsPageSize, maxUpnLookupAccounts = 1, 1
+	defer func() { managedAccountsPageSize, maxUpnLookupAccounts = pageSize, maxAccounts }()
+	if _, err = managedAccountObj.ManagedAccountGetByUserPrincipalName("svc_cloud@corp.example.com"); err == nil || !strings.Contains(err.Error(), "more than 1 managed accounts") {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	// Invalid lookup paths are skipped.
+	if secrets, err = managedAccountObj.GetSecrets([]string{"id:abc"}, "/"); err == nil || len(secrets) != 0 {
+		t.Errorf("Test case Failed %v, %v", secrets, err)
+	}
+}
@@ -9,21 +9,27 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/audit"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/fallback"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
-	backoff "github.com/cenkalti/backoff/v4"
 )
 
 // ManagedAccountstObj responsible for session requests.
 type ManagedAccountstObj struct {
 	log               logging.Logger
 	authenticationObj authentication.AuthenticationObj
+	auditTrail        *audit.Trail
+	fallbackStore     *fallback.Store
+	// credentialFlight shares the checkout and check-in of an account between concurrent callers.
+	credentialFlight utils.SingleFlight[credentialRetrieval]
 }
 
 // NewManagedAccountObj creates managed account obj
@@ -35,6 +41,17 @@ func NewManagedAccountObj(authentication authentication.AuthenticationObj, logge
 	return managedAccountObj, nil
 }
 
+// SetAuditTrail records every managed account credential read, create and delete to trail.
+func (managedAccountObj *ManagedAccountstObj) SetAuditTrail(trail *audit.Trail) {
+	managedAccountObj.auditTrail = trail
+}
+
+// SetFallbackStore saves the retrieved credentials to store and serves them from it
+// when Password Safe can not be reached, see fallback.Store.
+func (managedAccountObj *ManagedAccountstObj) SetFallbackStore(store *fallback.Store) {
+	managedAccountObj.fallbackStore = store
+}
+
 // GetSecrets is responsible for getting a list of managed account secret values based on the list of systems and account names.
 func (managedAccountObj *ManagedAccountstObj) GetSecrets(secretPaths []string, separator string) (map[string]string, error) {
 	return managedAccountObj.ManageAccountFlow(secretPaths, separator)
@@ -50,10 +67,17 @@ func (managedAccountObj *ManagedAccountstObj) GetSecret(secretPath string, separ
 
 // ManageAccountFlow is responsible for creating a dictionary of managed account system/name and secret key-value pairs.
 func (managedAccountObj *ManagedAccountstObj) ManageAccountFlow(secretsToRetrieve []string, separator string) (map[string]string, error) {
+	secretResults, err := managedAccountObj.ManageAccountResults(secretsToRetrieve, separator)
+	return fallback.Values(secretResults), err
+}
 
-	secretsToRetrieve = utils.ValidatePaths(secretsToRetrieve, true, separator, managedAccountObj.log)
+// ManageAccountResults is ManageAccountFlow reporting which values are served by the fallback store.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountResults(secretsToRetrieve []string, separator string) (map[string]fallback.Result, error) {
+
+	secretsToRetrieve = managedAccountObj.validatePaths(secretsToRetrieve, separator)
 	managedAccountObj.log.Info(fmt.Sprintf("Retrieving %v Secrets", len(secretsToRetrieve)))
-	secretDictionary := make(map[string]string)
+	secretDictionary := make(map[string]fallback.Result)
+	retrievedSecrets := make(map[string]string)
 	var saveLastErr error = nil
 
 	if len(secretsToRetrieve) == 0 {
@@ -61,55 +85,418 @@ func (managedAccountObj *ManagedAccountstObj) ManageAccountFlow(secretsToRetriev
 	}
 
 	for _, secretToRetrieve := range secretsToRetrieve {
-		retrievalData := strings.Split(secretToRetrieve, separator)
-		systemName := retrievalData[0]
-		accountName := retrievalData[1]
-
-		v := url.Values{}
-		v.Add("systemName", systemName)
-		v.Add("accountName", accountName)
+		lookup, _ := parseManagedAccountPath(secretToRetrieve, separator)
 
-		var err error
+		retrieval, shared, err := managedAccountObj.credentialFlight.Do(lookup.key(), func() (credentialRetrieval, error) {
+			return managedAccountObj.retrieveCredential(lookup)
+		})
+		if shared {
+			managedAccountObj.log.Debug(fmt.Sprintf("managed account %v retrieved by a concurrent identical request", secretToRetrieve))
+		}
+		// Every caller is audited, also the ones sharing the retrieval of another.
+		auditErr := err
+		if auditErr == nil {
+			auditErr = retrieval.checkInErr
+		}
+		managedAccountObj.auditTrail.Record(audit.OperationRead, secretToRetrieve, retrieval.accountId, auditErr)
 
-		ManagedAccountGetUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("ManagedAccounts").String() + "?" + v.Encode()
-		managedAccount, err := managedAccountObj.ManagedAccountGet(systemName, accountName, ManagedAccountGetUrl)
 		if err != nil {
+			if result, ok := managedAccountObj.fallbackStore.Fallback(fallback.KindManagedAccount, secretToRetrieve, err); ok {
+				secretDictionary[secretToRetrieve] = result
+				continue
+			}
 			saveLastErr = err
-			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v %v %v", err.Error(), systemName, separator, accountName))
+			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v", err.Error(), secretToRetrieve))
 			continue
 		}
 
-		ManagedAccountCreateRequestUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests").String()
-		requestId, err := managedAccountObj.ManagedAccountCreateRequest(managedAccount.SystemId, managedAccount.AccountId, ManagedAccountCreateRequestUrl)
-		if err != nil {
-			saveLastErr = err
-			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v %v %v", err.Error(), systemName, separator, accountName))
+		if retrieval.checkInErr != nil {
+			saveLastErr = retrieval.checkInErr
+			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v", retrieval.checkInErr.Error(), secretToRetrieve))
 			continue
 		}
 
-		CredentialByRequestIdUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Credentials", requestId).String()
-		secret, err := managedAccountObj.CredentialByRequestId(requestId, CredentialByRequestIdUrl)
+		retrievedSecrets[secretToRetrieve] = retrieval.password
+
+	}
+
+	managedAccountObj.fallbackStore.Save(fallback.KindManagedAccount, retrievedSecrets)
+	for secretToRetrieve, secretValue := range retrievedSecrets {
+		secretDictionary[secretToRetrieve] = fallback.Result{Value: secretValue}
+	}
+
+	return secretDictionary, saveLastErr
+}
+
+// ManageAccountBatchFlow is ManageAccountFlow in fewer round trips: the accounts are looked up
+// in one paged list of the managed accounts, the active requests of the caller are reused instead of creating new
+// ones and only the requests opened by the flow are checked in, so requests of other processes
+// are left open.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountBatchFlow(secretsToRetrieve []string, separator string) (map[string]string, error) {
+	secretResults, err := managedAccountObj.ManageAccountBatchResults(secretsToRetrieve, separator)
+	return fallback.Values(secretResults), err
+}
+
+// ManageAccountBatchResults is ManageAccountBatchFlow reporting which values are served by the fallback store.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountBatchResults(secretsToRetrieve []string, separator string) (map[string]fallback.Result, error) {
+
+	secretsToRetrieve = managedAccountObj.validatePaths(secretsToRetrieve, separator)
+	managedAccountObj.log.Info(fmt.Sprintf("Retrieving %v Secrets in batch", len(secretsToRetrieve)))
+	secretDictionary := make(map[string]fallback.Result)
+	retrievedSecrets := make(map[string]string)
+	var saveLastErr error = nil
+
+	if len(secretsToRetrieve) == 0 {
+		return secretDictionary, errors.New("empty managed account list")
+	}
+
+	// failed records the error of secretToRetrieve, served by the fallback store when possible.
+	failed := func(secretToRetrieve string, accountId string, err error) {
+		managedAccountObj.auditTrail.Record(audit.OperationRead, secretToRetrieve, accountId, err)
+		if result, ok := managedAccountObj.fallbackStore.Fallback(fallback.KindManagedAccount, secretToRetrieve, err); ok {
+			secretDictionary[secretToRetrieve] = result
+			return
+		}
+		saveLastErr = err
+		managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v", err.Error(), secretToRetrieve))
+	}
+
+	managedAccounts, err := managedAccountObj.listManagedAccounts(url.Values{}, 0)
+	if err == nil {
+		var activeRequests map[requestKey]string
+		activeRequests, err = managedAccountObj.activeRequests()
+		if err == nil {
+			for _, secretToRetrieve := range secretsToRetrieve {
+				lookup, _ := parseManagedAccountPath(secretToRetrieve, separator)
+				retrieval, accountId, err := managedAccountObj.batchRetrieve(lookup, managedAccounts, activeRequests)
+				if err != nil {
+					failed(secretToRetrieve, accountId, err)
+					continue
+				}
+
+				managedAccountObj.auditTrail.Record(audit.OperationRead, secretToRetrieve, accountId, retrieval.checkInErr)
+				if retrieval.checkInErr != nil {
+					saveLastErr = retrieval.checkInErr
+					managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v", retrieval.checkInErr.Error(), secretToRetrieve))
+					continue
+				}
+				retrievedSecrets[secretToRetrieve] = retrieval.password
+			}
+		}
+	}
+	if err != nil {
+		// Without the accounts or the active requests nothing can be retrieved safely.
+		for _, secretToRetrieve := range secretsToRetrieve {
+			failed(secretToRetrieve, "", err)
+		}
+	}
+
+	managedAccountObj.fallbackStore.Save(fallback.KindManagedAccount, retrievedSecrets)
+	for secretToRetrieve, secretValue := range retrievedSecrets {
+		secretDictionary[secretToRetrieve] = fallback.Result{Value: secretValue}
+	}
+
+	return secretDictionary, saveLastErr
+}
+
+// managedAccountsPageSize is the number of accounts requested per page of the ManagedAccounts list.
+var managedAccountsPageSize = 1000
+
+// listManagedAccounts pages through the ManagedAccounts endpoint filtered by query and returns all the accounts,
+// it fails once more than maxAccounts accounts are listed when maxAccounts is not 0.
+func (managedAccountObj *ManagedAccountstObj) listManagedAccounts(query url.Values, maxAccounts int) ([]entities.ManagedAccount, error) {
+	var managedAccounts []entities.ManagedAccount
+	for offset := 0; ; offset += managedAccountsPageSize {
+		pageQuery := url.Values{}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+		pageQuery.Set("limit", strconv.Itoa(managedAccountsPageSize))
+		pageQuery.Set("offset", strconv.Itoa(offset))
+
+		ManagedAccountsUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("ManagedAccounts").String() + "?" + pageQuery.Encode()
+		messageLog := fmt.Sprintf("%v %v", "GET", ManagedAccountsUrl)
+		managedAccountObj.log.Debug(messageLog)
+
+		response, err := managedAccountObj.sendRequestAndGetSingleString("GET", ManagedAccountsUrl, constants.ManagedAccountGet, bytes.Buffer{})
 		if err != nil {
-			saveLastErr = err
-			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v %v %v", err.Error(), systemName, separator, accountName))
+			return nil, err
+		}
+
+		var page []entities.ManagedAccount
+		if err = json.Unmarshal([]byte(response), &page); err != nil {
+			managedAccountObj.log.Error(err.Error())
+			return nil, err
+		}
+		managedAccounts = append(managedAccounts, page...)
+
+		// A short page is the last one.
+		if len(page) < managedAccountsPageSize {
+			return managedAccounts, nil
+		}
+		if maxAccounts > 0 && len(managedAccounts) >= maxAccounts {
+			return nil, fmt.Errorf("more than %v managed accounts are listed", maxAccounts)
+		}
+	}
+}
+
+// requestKey identifies the account of a request.
+type requestKey struct {
+	systemId  int
+	accountId int
+}
+
+// activeRequests returns the IDs of the active credential requests of the caller by account.
+func (managedAccountObj *ManagedAccountstObj) activeRequests() (map[requestKey]string, error) {
+	ManagedAccountGetRequestsUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests").String() + "?status=active"
+	requests, err := managedAccountObj.ManagedAccountGetRequests(ManagedAccountGetRequestsUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRequests := make(map[requestKey]string, len(requests))
+	for _, request := range requests {
+		// Only view requests give access to the credential.
+		if request.AccessType != "" && !strings.EqualFold(request.AccessType, "View") {
 			continue
 		}
+		activeRequests[requestKey{request.SystemID, request.AccountID}] = strconv.Itoa(request.RequestID)
+	}
+	return activeRequests, nil
+}
+
+// batchRetrieve retrieves the credential of the account selected by lookup, reusing the active request
+// of the account when there is one and checking in the request otherwise. It returns the account
+// ID, empty when the account was not found.
+func (managedAccountObj *ManagedAccountstObj) batchRetrieve(lookup managedAccountLookup, managedAccounts []entities.ManagedAccount, activeRequests map[requestKey]string) (credentialRetrieval, string, error) {
+	index := slices.IndexFunc(managedAccounts, lookup.matches)
+	if index < 0 {
+		return credentialRetrieval{}, "", utils.NewNotFoundError("managed account %v was not found", lookup)
+	}
+	managedAccount := managedAccounts[index]
+	accountId := strconv.Itoa(managedAccount.AccountId)
+
+	var err error
+	requestId, reused := activeRequests[requestKey{managedAccount.SystemId, managedAccount.AccountId}]
+	if reused {
+		managedAccountObj.log.Debug(fmt.Sprintf("reusing the active request of %v", lookup))
+	} else {
+		ManagedAccountCreateRequestUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests").String()
+		requestId, err = managedAccountObj.ManagedAccountCreateRequest(managedAccount.SystemId, managedAccount.AccountId, ManagedAccountCreateRequestUrl)
+		if err != nil {
+			return credentialRetrieval{}, accountId, err
+		}
+	}
 
+	CredentialByRequestIdUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Credentials", requestId).String()
+	secret, err := managedAccountObj.CredentialByRequestId(requestId, CredentialByRequestIdUrl)
+
+	var checkInErr error
+	if !reused {
+		// The request opened here is checked in even when the credential could not be retrieved.
 		ManagedAccountRequestCheckInUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests", requestId, "checkin").String()
-		_, err = managedAccountObj.ManagedAccountRequestCheckIn(requestId, ManagedAccountRequestCheckInUrl)
+		_, checkInErr = managedAccountObj.ManagedAccountRequestCheckIn(requestId, ManagedAccountRequestCheckInUrl)
+	}
+	if err != nil {
+		return credentialRetrieval{}, accountId, err
+	}
+	if checkInErr != nil {
+		return credentialRetrieval{checkInErr: checkInErr}, accountId, nil
+	}
 
-		if err != nil {
-			saveLastErr = err
-			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v %v %v", err.Error(), systemName, separator, accountName))
-			continue
+	secretValue, _ := strconv.Unquote(secret)
+	logging.RegisterSecret(managedAccountObj.log, secretValue)
+	return credentialRetrieval{password: secretValue}, accountId, nil
+}
+
+// ManagedAccountGetRequests calls Password Safe API Requests endpoint and returns the requests of the caller.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountGetRequests(url string) ([]entities.ManagedAccountRequest, error) {
+	messageLog := fmt.Sprintf("%v %v", "GET", url)
+	managedAccountObj.log.Debug(messageLog)
+
+	response, err := managedAccountObj.sendRequestAndGetSingleString("GET", url, constants.ManagedAccountGetRequests, bytes.Buffer{})
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []entities.ManagedAccountRequest
+	if err = json.Unmarshal([]byte(response), &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// credentialRetrieval is the result of retrieveCredential.
+type credentialRetrieval struct {
+	password string
+	// accountId is the account ID as audited, empty when the account was not found.
+	accountId string
+	// checkInErr is set when the credential was retrieved but the request could not be checked in.
+	checkInErr error
+}
+
+// retrieveCredential checks out the credential of the account selected by lookup and checks the request in,
+// the error is the checkout error. It is not audited, callers record the returned account ID.
+func (managedAccountObj *ManagedAccountstObj) retrieveCredential(lookup managedAccountLookup) (credentialRetrieval, error) {
+	checkout, err := managedAccountObj.checkout(lookup)
+	if err != nil {
+		return credentialRetrieval{accountId: checkout.accountId()}, err
+	}
+
+	ManagedAccountRequestCheckInUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests", checkout.RequestId, "checkin").String()
+	_, err = managedAccountObj.ManagedAccountRequestCheckIn(checkout.RequestId, ManagedAccountRequestCheckInUrl)
+	if err != nil {
+		return credentialRetrieval{accountId: checkout.accountId(), checkInErr: err}, nil
+	}
+
+	return credentialRetrieval{password: checkout.Password, accountId: checkout.accountId()}, nil
+}
+
+// managedAccountCheckout wraps entities.ManagedAccountCheckout for audit helpers.
+type managedAccountCheckout entities.ManagedAccountCheckout
+
+// accountId returns the account ID as audited, empty when the account was not found.
+func (checkout managedAccountCheckout) accountId() string {
+	if checkout.AccountId == 0 {
+		return ""
+	}
+	return strconv.Itoa(checkout.AccountId)
+}
+
+// checkout looks up the account selected by lookup, creates a request and retrieves the credential.
+// The request is left open; on error it is checked in and the returned value carries the account
+// IDs found so far.
+func (managedAccountObj *ManagedAccountstObj) checkout(lookup managedAccountLookup) (managedAccountCheckout, error) {
+	checkout, err := managedAccountObj.openRequest(lookup)
+	if err != nil {
+		return checkout, err
+	}
+
+	CredentialByRequestIdUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Credentials", checkout.RequestId).String()
+	secret, err := managedAccountObj.CredentialByRequestId(checkout.RequestId, CredentialByRequestIdUrl)
+	if err != nil {
+		// The request is of no use without the credential.
+		ManagedAccountRequestCheckInUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests", checkout.RequestId, "checkin").String()
+		if _, checkInErr := managedAccountObj.ManagedAccountRequestCheckIn(checkout.RequestId, ManagedAccountRequestCheckInUrl); checkInErr != nil {
+			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v", checkInErr.Error(), lookup))
 		}
+		checkout.RequestId = ""
+		return checkout, err
+	}
 
-		secretValue, _ := strconv.Unquote(secret)
-		secretDictionary[secretToRetrieve] = secretValue
+	secretValue, _ := strconv.Unquote(secret)
+	logging.RegisterSecret(managedAccountObj.log, secretValue)
+	checkout.Password = secretValue
+
+	return checkout, nil
+}
 
+// openRequest looks up the account selected by lookup and creates a request, the returned
+// value carries the account IDs found so far on error.
+func (managedAccountObj *ManagedAccountstObj) openRequest(lookup managedAccountLookup) (managedAccountCheckout, error) {
+	checkout := managedAccountCheckout{SystemName: lookup.systemName, AccountName: lookup.accountName}
+
+	managedAccount, err := managedAccountObj.lookupManagedAccount(lookup)
+	if err != nil {
+		return checkout, err
+	}
+	if managedAccount.SystemName != "" {
+		checkout.SystemName = managedAccount.SystemName
 	}
+	if managedAccount.AccountName != "" {
+		checkout.AccountName = managedAccount.AccountName
+	}
+	checkout.SystemId = managedAccount.SystemId
+	checkout.AccountId = managedAccount.AccountId
 
-	return secretDictionary, saveLastErr
+	ManagedAccountCreateRequestUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests").String()
+	requestId, err := managedAccountObj.ManagedAccountCreateRequest(managedAccount.SystemId, managedAccount.AccountId, ManagedAccountCreateRequestUrl)
+	if err != nil {
+		return checkout, err
+	}
+	checkout.RequestId = requestId
+
+	return checkout, nil
+}
+
+// ManageAccountCheckoutFlow retrieves the credential of secretPath (system/account) and keeps
+// the request open, callers must call ManageAccountCheckInFlow with the returned request ID.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountCheckoutFlow(secretPath string, separator string) (entities.ManagedAccountCheckout, error) {
+	secretPath, lookup, err := managedAccountObj.splitManagedAccountPath(secretPath, separator)
+	if err != nil {
+		return entities.ManagedAccountCheckout{}, err
+	}
+
+	checkout, err := managedAccountObj.checkout(lookup)
+	managedAccountObj.auditTrail.Record(audit.OperationCheckout, secretPath, checkout.accountId(), err)
+	if err != nil {
+		return entities.ManagedAccountCheckout{}, err
+	}
+
+	return entities.ManagedAccountCheckout(checkout), nil
+}
+
+// ManageAccountCheckInFlow checks in a request opened by ManageAccountCheckoutFlow.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountCheckInFlow(requestId string) error {
+	requestId = strings.TrimSpace(requestId)
+	if requestId == "" {
+		return errors.New("request id must not be empty")
+	}
+
+	ManagedAccountRequestCheckInUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests", requestId, "checkin").String()
+	_, err := managedAccountObj.ManagedAccountRequestCheckIn(requestId, ManagedAccountRequestCheckInUrl)
+	managedAccountObj.auditTrail.Record(audit.OperationCheckIn, "", "", err)
+	return err
+}
+
+// ManageAccountRotateFlow changes the credential of secretPath (system/account) in Password Safe,
+// when queue is true the change is queued instead of performed immediately.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountRotateFlow(secretPath string, separator string, queue bool) error {
+	secretPath, lookup, err := managedAccountObj.splitManagedAccountPath(secretPath, separator)
+	if err != nil {
+		return err
+	}
+
+	managedAccount, err := managedAccountObj.lookupManagedAccount(lookup)
+	if err != nil {
+		managedAccountObj.auditTrail.Record(audit.OperationRotate, secretPath, "", err)
+		return err
+	}
+
+	ChangeCredentialsUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("ManagedAccounts", strconv.Itoa(managedAccount.AccountId), "Credentials", "Change").String()
+	err = managedAccountObj.ManagedAccountChangeCredentials(queue, ChangeCredentialsUrl)
+	managedAccountObj.auditTrail.Record(audit.OperationRotate, secretPath, strconv.Itoa(managedAccount.AccountId), err)
+	return err
+}
+
+// splitManagedAccountPath validates a single managed account path and returns it with its lookup.
+func (managedAccountObj *ManagedAccountstObj) splitManagedAccountPath(secretPath string, separator string) (string, managedAccountLookup, error) {
+	if lookupKind(secretPath) != "" {
+		lookup, err := parseManagedAccountPath(secretPath, separator)
+		return secretPath, lookup, err
+	}
+
+	if !strings.Contains(secretPath, separator) {
+		return "", managedAccountLookup{}, fmt.Errorf("invalid managed account path %v, expected system%vaccount", secretPath, separator)
+	}
+
+	secretPaths := utils.ValidatePaths([]string{secretPath}, true, separator, managedAccountObj.log)
+	if len(secretPaths) == 0 {
+		return "", managedAccountLookup{}, fmt.Errorf("invalid managed account path: %v", secretPath)
+	}
+
+	lookup, err := parseManagedAccountPath(secretPaths[0], separator)
+	return secretPaths[0], lookup, err
+}
+
+// ManagedAccountChangeCredentials calls Password Safe API ManagedAccounts/<id>/Credentials/Change endpoint.
+func (managedAccountObj *ManagedAccountstObj) ManagedAccountChangeCredentials(queue bool, url string) error {
+	messageLog := fmt.Sprintf("%v %v", "POST", url)
+	managedAccountObj.log.Debug(messageLog)
+
+	b := bytes.NewBufferString(fmt.Sprintf(`{"Queue":%v}`, queue))
+	_, err := managedAccountObj.sendRequestAndGetSingleString("POST", url, constants.ManagedAccountChangeCredentials, *b)
+	return err
 }
 
 // ManagedAccountGet is responsible for retrieving a managed account secret based on the system and name.
@@ -132,14 +519,14 @@ func (managedAccountObj *ManagedAccountstObj) ManagedAccountGet(systemName strin
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = managedAccountObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, managedAccountObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = managedAccountObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		if technicalError != nil {
 			return technicalError
 		}
 		return nil
 
-	}, managedAccountObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.ManagedAccount{}, technicalError
@@ -167,12 +554,15 @@ func (managedAccountObj *ManagedAccountstObj) ManagedAccountGet(systemName strin
 
 }
 
+// RequestDurationMinutes is the duration of the requests created by ManagedAccountCreateRequest.
+const RequestDurationMinutes = 5
+
 // ManagedAccountCreateRequest calls Secret Safe API Requests enpoint and returns a request Id as string.
 func (managedAccountObj *ManagedAccountstObj) ManagedAccountCreateRequest(systemName int, accountName int, url string) (string, error) {
 	messageLog := fmt.Sprintf("%v %v", "POST", url)
 	managedAccountObj.log.Debug(messageLog)
 
-	data := fmt.Sprintf(`{"SystemID":%v, "AccountID":%v, "DurationMinutes":5, "Reason":"Tesr", "ConflictOption": "reuse"}`, systemName, accountName)
+	data := fmt.Sprintf(`{"SystemID":%v, "AccountID":%v, "DurationMinutes":%v, "Reason":"Tesr", "ConflictOption": "reuse"}`, systemName, accountName, RequestDurationMinutes)
 	b := bytes.NewBufferString(data)
 
 	return managedAccountObj.sendRequestAndGetSingleString("POST", url, constants.ManagedAccountCreateRequest, *b)
@@ -182,16 +572,18 @@ func (managedAccountObj *ManagedAccountstObj) ManagedAccountCreateRequest(system
 // CredentialByRequestId calls Secret Safe API Credentials/<request_id>
 // enpoint and returns secret value by request Id.
 func (managedAccountObj *ManagedAccountstObj) CredentialByRequestId(requestId string, url string) (string, error) {
+	logging.RegisterSecret(managedAccountObj.log, requestId)
 	messageLog := fmt.Sprintf("%v %v", "GET", url)
-	managedAccountObj.log.Debug(strings.ReplaceAll(messageLog, requestId, "****"))
+	managedAccountObj.log.Debug(logging.RedactRequestIDs(messageLog))
 	return managedAccountObj.sendRequestAndGetSingleString("GET", url, constants.CredentialByRequestId, bytes.Buffer{})
 
 }
 
 // ManagedAccountRequestCheckIn calls Secret Safe API "Requests/<request_id>/checkin enpoint.
 func (managedAccountObj *ManagedAccountstObj) ManagedAccountRequestCheckIn(requestId string, url string) (string, error) {
+	logging.RegisterSecret(managedAccountObj.log, requestId)
 	messageLog := fmt.Sprintf("%v %v", "PUT", url)
-	managedAccountObj.log.Debug(strings.ReplaceAll(messageLog, requestId, "****"))
+	managedAccountObj.log.Debug(logging.RedactRequestIDs(messageLog))
 
 	data := "{}"
 	b := bytes.NewBufferString(data)
@@ -210,10 +602,10 @@ func (managedAccountObj *ManagedAccountstObj) ManagedAccountRequestCheckIn(reque
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = managedAccountObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, managedAccountObj.authenticationObj.ExponentialBackOff, func() error {
 		_, _, technicalError, businessError = managedAccountObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, managedAccountObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return "", technicalError
@@ -228,6 +620,19 @@ func (managedAccountObj *ManagedAccountstObj) ManagedAccountRequestCheckIn(reque
 
 // ManageAccountCreateFlow is responsible for creating a managed accounts in Password Safe.
 func (managedAccountObj *ManagedAccountstObj) ManageAccountCreateFlow(systemNameTarget string, accountDetails entities.AccountDetails) (entities.CreateManagedAccountsResponse, error) {
+	createResponse, err := managedAccountObj.manageAccountCreateFlow(systemNameTarget, accountDetails)
+
+	accountId := ""
+	if err == nil {
+		accountId = strconv.Itoa(createResponse.ManagedAccountID)
+	}
+	managedAccountObj.auditTrail.Record(audit.OperationCreate, systemNameTarget+"/"+accountDetails.AccountName, accountId, err)
+
+	return createResponse, err
+}
+
+// manageAccountCreateFlow validates accountDetails, looks up the managed system and creates the account.
+func (managedAccountObj *ManagedAccountstObj) manageAccountCreateFlow(systemNameTarget string, accountDetails entities.AccountDetails) (entities.CreateManagedAccountsResponse, error) {
 
 	var managedSystem *entities.ManagedSystemResponse
 	var createResponse entities.CreateManagedAccountsResponse
@@ -253,7 +658,7 @@ func (managedAccountObj *ManagedAccountstObj) ManageAccountCreateFlow(systemName
 	}
 
 	if managedSystem == nil {
-		return createResponse, fmt.Errorf("managed system %v was not found in managed system list", systemNameTarget)
+		return createResponse, utils.NewNotFoundError("managed system %v was not found in managed system list", systemNameTarget)
 	}
 
 	ManagedAccountCreateManagedAccountUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("ManagedSystems", fmt.Sprintf("%d", managedSystem.ManagedSystemID), "ManagedAccounts").String()
@@ -296,10 +701,10 @@ func (managedAccountObj *ManagedAccountstObj) ManagedAccountCreateManagedAccount
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = managedAccountObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, managedAccountObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = managedAccountObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, managedAccountObj.authenticationObj.ExponentialBackOff)
+	})
 
 	var CreateManagedAccountsResponse entities.CreateManagedAccountsResponse
 
@@ -349,14 +754,14 @@ func (managedAccountObj *ManagedAccountstObj) ManagedSystemGetSystems(url string
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = managedAccountObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, managedAccountObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = managedAccountObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		if technicalError != nil {
 			return technicalError
 		}
 		return nil
 
-	}, managedAccountObj.authenticationObj.ExponentialBackOff)
+	})
 
 	var managedSystemObject []entities.ManagedSystemResponse
 
@@ -442,10 +847,10 @@ func (managedAccountObj *ManagedAccountstObj) sendRequestAndGetSingleString(http
 		ContentType: "application/json",
 		ApiVersion:  "",
 	}
-	technicalError = backoff.Retry(func() error {
+	technicalError = managedAccountObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, managedAccountObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = managedAccountObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, managedAccountObj.authenticationObj.ExponentialBackOff)
+	})
 	if technicalError != nil {
 		return "", technicalError
 	}
@@ -482,16 +887,19 @@ func (managedAccountObj *ManagedAccountstObj) DeleteManagedAccountById(managedAc
 	var technicalError error
 	var businessError error
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = managedAccountObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, managedAccountObj.authenticationObj.ExponentialBackOff, func() error {
 		_, _, technicalError, businessError = managedAccountObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, managedAccountObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
+		managedAccountObj.auditTrail.Record(audit.OperationDelete, "ManagedAccounts/"+strconv.Itoa(managedAccountID), strconv.Itoa(managedAccountID), technicalError)
 		return technicalError
 	}
 	if businessError != nil {
+		managedAccountObj.auditTrail.Record(audit.OperationDelete, "ManagedAccounts/"+strconv.Itoa(managedAccountID), strconv.Itoa(managedAccountID), businessError)
 		return businessError
 	}
+	managedAccountObj.auditTrail.Record(audit.OperationDelete, "ManagedAccounts/"+strconv.Itoa(managedAccountID), strconv.Itoa(managedAccountID), nil)
 	return nil
 }
@@ -4,17 +4,25 @@
 package managed_accounts
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/audit"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/fallback"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
 
@@ -1406,3 +1414,444 @@ func TestDeleteManagedAccountById_ServerError(t *testing.T) {
 		t.Errorf("Expected error for 500 response, got nil")
 	}
 }
+
+func TestManageAccountCheckoutAndCheckInFlow(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	checkedIn := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+
+		case "/ManagedAccounts":
+			_, _ = w.Write([]byte(`{"SystemId":1,"AccountId":10}`))
+
+		case "/Requests":
+			_, _ = w.Write([]byte(`124`))
+
+		case "/Credentials/124":
+			if checkedIn {
+				t.Error("credential retrieved after check-in")
+			}
+			_, _ = w.Write([]byte(`"fake_credential"`))
+
+		case "/Requests/124/checkin":
+			checkedIn = true
+			_, _ = w.Write([]byte(``))
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	checkout, err := managedAccountObj.ManageAccountCheckoutFlow("system01/account01", "/")
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	expected := entities.ManagedAccountCheckout{SystemName: "system01", AccountName: "account01", SystemId: 1, AccountId: 10, RequestId: "124", Password: "fake_credential"}
+	if checkout != expected {
+		t.Errorf("Test case Failed %v, %v", checkout, expected)
+	}
+	if checkedIn {
+		t.Errorf("checkout must keep the request open")
+	}
+
+	if err = managedAccountObj.ManageAccountCheckInFlow(checkout.RequestId); err != nil || !checkedIn {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	if err = managedAccountObj.ManageAccountCheckInFlow(" "); err == nil {
+		t.Errorf("expected an error for an empty request id")
+	}
+}
+
+func TestManageAccountRotateFlow(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+
+		case "/ManagedAccounts":
+			_, _ = w.Write([]byte(`{"SystemId":1,"AccountId":10}`))
+
+		case "/ManagedAccounts/10/Credentials/Change":
+			bodyBytes, _ := io.ReadAll(r.Body)
+			body = string(bodyBytes)
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	if err := managedAccountObj.ManageAccountRotateFlow("system01/account01", "/", true); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if body != `{"Queue":true}` {
+		t.Errorf("Test case Failed %v", body)
+	}
+
+	if err := managedAccountObj.ManageAccountRotateFlow("system01", "/", false); err == nil {
+		t.Errorf("expected an error for an invalid path")
+	}
+}
+
+func TestManageAccountFlowFallbackStore(t *testing.T) {
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Auth/SignAppin":
+			_, _ = w.Write([]byte(`{"UserId":1, "EmailAddress":"test@beyondtrust.com"}`))
+		case "/Auth/Signout":
+			_, _ = w.Write([]byte(``))
+		case "/ManagedAccounts":
+			_, _ = w.Write([]byte(`{"SystemId":1,"AccountId":10}`))
+		case "/Requests":
+			_, _ = w.Write([]byte(`124`))
+		case "/Credentials/124":
+			_, _ = w.Write([]byte(`"fake_credential"`))
+		case "/Requests/124/checkin":
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	store, err := fallback.NewStore(filepath.Join(t.TempDir(), "lkg.bin"), bytes.Repeat([]byte{1}, 32), time.Hour, zapLogger)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	managedAccountObj.SetFallbackStore(store)
+
+	if _, err = managedAccountObj.ManageAccountFlow([]string{"system01/account01"}, "/"); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	// Password Safe can not be reached anymore.
+	server.Close()
+
+	results, err := managedAccountObj.ManageAccountResults([]string{"system01/account01"}, "/")
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if result := results["system01/account01"]; result.Value != "fake_credential" || !result.Stale {
+		t.Errorf("Test case Failed %+v", result)
+	}
+}
+
+func TestManageAccountFlowSharesConcurrentRequests(t *testing.T) {
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var requests atomic.Int32
+	var checkIns atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ManagedAccounts":
+			<-release
+			_, _ = w.Write([]byte(`{"SystemId":1,"AccountId":10}`))
+		case "/Requests":
+			requests.Add(1)
+			_, _ = w.Write([]byte(`124`))
+		case "/Credentials/124":
+			_, _ = w.Write([]byte(`"fake_credential"`))
+		case "/Requests/124/checkin":
+			checkIns.Add(1)
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	var events atomic.Int32
+	managedAccountObj.SetAuditTrail(audit.NewTrail(audit.SinkFunc(func(event audit.Event) {
+		if event.Operation == audit.OperationRead && event.Path == "system01/account01" && event.SecretID == "10" && event.Outcome == audit.OutcomeSuccess {
+			events.Add(1)
+		}
+	}), "ci-pipeline"))
+
+	var waitGroup sync.WaitGroup
+	for range 10 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if secret, err := managedAccountObj.GetSecret("system01/account01", "/"); secret != "fake_credential" || err != nil {
+				t.Errorf("Test case Failed %v, %v", secret, err)
+			}
+		}()
+	}
+
+	// Let the goroutines join the checkout in flight.
+	time.Sleep(200 * time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+
+	if requests.Load() != 1 || checkIns.Load() != 1 {
+		t.Errorf("Test case Failed, %v requests and %v check-ins, expected 1", requests.Load(), checkIns.Load())
+	}
+	// Every caller is audited, not only the one making the calls.
+	if events.Load() != 10 {
+		t.Errorf("Test case Failed, %v audit events, expected 10", events.Load())
+	}
+}
+
+func TestManageAccountBatchFlow(t *testing.T) {
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var createdRequests atomic.Int32
+	checkIns := map[string]int{}
+	var checkInsLock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ManagedAccounts":
+			_, _ = w.Write([]byte(`[{"SystemId":1,"SystemName":"system01","AccountId":10,"AccountName":"account01"},
+				{"SystemId":1,"SystemName":"system01","AccountId":11,"AccountName":"account02"}]`))
+		case r.URL.Path == "/Requests" && r.Method == http.MethodGet:
+			if r.URL.Query().Get("status") != "active" {
+				t.Errorf("Test case Failed, query %v", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"RequestID":200,"SystemID":1,"AccountID":10,"Status":"Active","AccessType":"View"},
+				{"RequestID":300,"SystemID":1,"AccountID":11,"Status":"Active","AccessType":"RDP"}]`))
+		case r.URL.Path == "/Requests" && r.Method == http.MethodPost:
+			createdRequests.Add(1)
+			_, _ = w.Write([]byte(`124`))
+		case r.URL.Path == "/Credentials/200":
+			_, _ = w.Write([]byte(`"reused_credential"`))
+		case r.URL.Path == "/Credentials/124":
+			_, _ = w.Write([]byte(`"fake_credential"`))
+		case strings.HasSuffix(r.URL.Path, "/checkin"):
+			checkInsLock.Lock()
+			checkIns[r.URL.Path]++
+			checkInsLock.Unlock()
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	secrets, err := managedAccountObj.ManageAccountBatchFlow([]string{"system01/account01", "SYSTEM01/account02", "system01/account03"}, "/")
+
+	if secrets["system01/account01"] != "reused_credential" || secrets["SYSTEM01/account02"] != "fake_credential" || len(secrets) != 2 {
+		t.Errorf("Test case Failed %v", secrets)
+	}
+	if err == nil || !strings.Contains(err.Error(), "system01/account03 was not found") {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	// Only the request opened by the flow is checked in, the active view request is left open.
+	if createdRequests.Load() != 1 || len(checkIns) != 1 || checkIns["/Requests/124/checkin"] != 1 {
+		t.Errorf("Test case Failed, %v requests created, check-ins %v", createdRequests.Load(), checkIns)
+	}
+}
+
+func TestManageAccountBatchFlowPagesAccounts(t *testing.T) {
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	pageSize := managedAccountsPageSize
+	managedAccountsPageSize = 2
+	defer func() { managedAccountsPageSize = pageSize }()
+
+	var pages atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ManagedAccounts":
+			pages.Add(1)
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("Test case Failed, query %v", r.URL.RawQuery)
+			}
+			switch r.URL.Query().Get("offset") {
+			case "0":
+				_, _ = w.Write([]byte(`[{"SystemId":1,"SystemName":"system01","AccountId":10,"AccountName":"account01"},
+					{"SystemId":1,"SystemName":"system01","AccountId":11,"AccountName":"account02"}]`))
+			case "2":
+				_, _ = w.Write([]byte(`[{"SystemId":1,"SystemName":"system01","AccountId":12,"AccountName":"account03"}]`))
+			default:
+				_, _ = w.Write([]byte(`[]`))
+			}
+		case r.URL.Path == "/Requests" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		case r.URL.Path == "/Requests" && r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`124`))
+		case r.URL.Path == "/Credentials/124":
+			_, _ = w.Write([]byte(`"fake_credential"`))
+		case strings.HasSuffix(r.URL.Path, "/checkin"):
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	// account03 is on the second page.
+	secrets, err := managedAccountObj.ManageAccountBatchFlow([]string{"system01/account03"}, "/")
+	if err != nil || secrets["system01/account03"] != "fake_credential" {
+		t.Errorf("Test case Failed %v, %v", secrets, err)
+	}
+	if pages.Load() != 2 {
+		t.Errorf("Test case Failed, %v pages requested", pages.Load())
+	}
+}
+
+func TestManageAccountBatchFlowRequestsTechnicalError(t *testing.T) {
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var createdRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ManagedAccounts":
+			_, _ = w.Write([]byte(`[{"SystemId":1,"SystemName":"system01","AccountId":10,"AccountName":"account01"}]`))
+		case r.URL.Path == "/Requests" && r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusBadGateway)
+		case r.URL.Path == "/Requests" && r.Method == http.MethodPost:
+			createdRequests.Add(1)
+			_, _ = w.Write([]byte(`124`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	// Without the active requests no request is created, it could check in the request of another process.
+	secrets, err := managedAccountObj.ManageAccountBatchFlow([]string{"system01/account01"}, "/")
+	if err == nil || len(secrets) != 0 || createdRequests.Load() != 0 {
+		t.Errorf("Test case Failed %v, %v, %v requests created", secrets, err, createdRequests.Load())
+	}
+}
+
+func TestCredentialByRequestIdRegistersRequestId(t *testing.T) {
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`"fake_credential"`))
+	}))
+	defer server.Close()
+
+	var logBuffer bytes.Buffer
+	logger := logging.NewRedactingLogger(logging.NewLogLogger(log.New(&logBuffer, "", 0)))
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, logger)
+
+	if _, err := managedAccountObj.CredentialByRequestId("8675309", server.URL+"/Credentials/8675309"); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	// The request ID is masked wherever it is logged, not only in request paths.
+	logger.Info("request 8675309 opened")
+	if strings.Contains(logBuffer.String(), "8675309") {
+		t.Errorf("Test case Failed, the log leaks the request ID: %v", logBuffer.String())
+	}
+}
+
+func TestRequestIdIsMaskedWithPlainLogger(t *testing.T) {
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`"fake_credential"`))
+	}))
+	defer server.Close()
+
+	// A logger that does not scrub registered values.
+	var logBuffer bytes.Buffer
+	logger := logging.NewLogLogger(log.New(&logBuffer, "", 0))
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, logger)
+
+	if _, err := managedAccountObj.CredentialByRequestId("8675309", server.URL+"/Credentials/8675309"); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if _, err := managedAccountObj.ManagedAccountRequestCheckIn("8675309", server.URL+"/Requests/8675309/checkin"); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	if strings.Contains(logBuffer.String(), "8675309") {
+		t.Errorf("Test case Failed, the log leaks the request ID: %v", logBuffer.String())
+	}
+}
+
+func TestManageAccountCheckoutFlowChecksInOnCredentialError(t *testing.T) {
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var checkIns atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ManagedAccounts":
+			_, _ = w.Write([]byte(`{"SystemId":1,"SystemName":"system01","AccountId":10,"AccountName":"account01"}`))
+		case "/Requests":
+			_, _ = w.Write([]byte(`124`))
+		case "/Credentials/124":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/Requests/124/checkin":
+			checkIns.Add(1)
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	if _, err := managedAccountObj.ManageAccountCheckoutFlow("system01/account01", "/"); err == nil {
+		t.Error("Test case Failed, the credential error must be returned")
+	}
+	if checkIns.Load() != 1 {
+		t.Errorf("Test case Failed, %v check-ins", checkIns.Load())
+	}
+
+	// The flows retrieving and checking in the credential do not leak the request either.
+	if _, err := managedAccountObj.ManageAccountFlow([]string{"system01/account01"}, "/"); err == nil {
+		t.Error("Test case Failed, the credential error must be returned")
+	}
+	if checkIns.Load() != 2 {
+		t.Errorf("Test case Failed, %v check-ins", checkIns.Load())
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package managed_accounts implements functions to retrieve managed accounts
+package managed_accounts
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/audit"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"golang.org/x/crypto/ssh"
+)
+
+// Credential types of CredentialByRequestIdAndType.
+const (
+	CredentialTypePassword   = "password"
+	CredentialTypeDssKey     = "dsskey"
+	CredentialTypePassphrase = "passphrase"
+)
+
+// CredentialByRequestIdAndType calls Secret Safe API Credentials/<request_id>?type=<credentialType>
+// endpoint and returns the credential of the type, registered for redaction.
+func (managedAccountObj *ManagedAccountstObj) CredentialByRequestIdAndType(requestId string, credentialType string) (string, error) {
+	CredentialByRequestIdUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Credentials", requestId).String() + "?type=" + url.QueryEscape(credentialType)
+	secret, err := managedAccountObj.CredentialByRequestId(requestId, CredentialByRequestIdUrl)
+	if err != nil {
+		return "", err
+	}
+
+	secretValue, err := strconv.Unquote(secret)
+	if err != nil {
+		// Keys are returned as JSON strings, anything else is returned as is.
+		secretValue = secret
+	}
+	registerKeyMaterial(managedAccountObj.log, secretValue)
+	return secretValue, nil
+}
+
+// registerKeyMaterial registers value for redaction, with each line of multi-line values
+// such as PEM keys so that a key logged line by line or JSON escaped is masked too.
+func registerKeyMaterial(logger logging.Logger, value string) {
+	logging.RegisterSecret(logger, value, strings.ReplaceAll(value, "\n", `\n`))
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		// The PEM armor lines are not secret.
+		if len(line) >= 16 && !strings.HasPrefix(line, "-----") {
+			logging.RegisterSecret(logger, line)
+		}
+	}
+}
+
+// ManageAccountSSHKeyFlow retrieves the SSH private key of secretPath, with its passphrase when the
+// key is encrypted, and checks the request in. The path accepts the lookup syntax of ManageAccountFlow.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountSSHKeyFlow(secretPath string, separator string) (entities.ManagedAccountSSHKey, error) {
+	secretPath, lookup, err := managedAccountObj.splitManagedAccountPath(secretPath, separator)
+	if err != nil {
+		return entities.ManagedAccountSSHKey{}, err
+	}
+
+	checkout, err := managedAccountObj.openRequest(lookup)
+	if err != nil {
+		managedAccountObj.auditTrail.Record(audit.OperationRead, secretPath, checkout.accountId(), err)
+		return entities.ManagedAccountSSHKey{}, err
+	}
+
+	sshKey, err := managedAccountObj.sshKey(checkout)
+
+	ManagedAccountRequestCheckInUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests", checkout.RequestId, "checkin").String()
+	if _, checkInErr := managedAccountObj.ManagedAccountRequestCheckIn(checkout.RequestId, ManagedAccountRequestCheckInUrl); checkInErr != nil && err == nil {
+		err = checkInErr
+	}
+	managedAccountObj.auditTrail.Record(audit.OperationRead, secretPath, checkout.accountId(), err)
+	if err != nil {
+		return entities.ManagedAccountSSHKey{}, err
+	}
+
+	return sshKey, nil
+}
+
+// ManageAccountSSHKeyCheckoutFlow is ManageAccountSSHKeyFlow keeping the request open for as long as
+// the key is used, callers must call ManageAccountCheckInFlow with the RequestId of the returned key.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountSSHKeyCheckoutFlow(secretPath string, separator string) (entities.ManagedAccountSSHKey, error) {
+	secretPath, lookup, err := managedAccountObj.splitManagedAccountPath(secretPath, separator)
+	if err != nil {
+		return entities.ManagedAccountSSHKey{}, err
+	}
+
+	checkout, err := managedAccountObj.openRequest(lookup)
+	if err != nil {
+		managedAccountObj.auditTrail.Record(audit.OperationCheckout, secretPath, checkout.accountId(), err)
+		return entities.ManagedAccountSSHKey{}, err
+	}
+
+	sshKey, err := managedAccountObj.sshKey(checkout)
+	managedAccountObj.auditTrail.Record(audit.OperationCheckout, secretPath, checkout.accountId(), err)
+	if err != nil {
+		// The request is of no use without a key.
+		ManagedAccountRequestCheckInUrl := managedAccountObj.authenticationObj.ApiUrl.JoinPath("Requests", checkout.RequestId, "checkin").String()
+		if _, checkInErr := managedAccountObj.ManagedAccountRequestCheckIn(checkout.RequestId, ManagedAccountRequestCheckInUrl); checkInErr != nil {
+			managedAccountObj.log.Error(fmt.Sprintf("%v secretsPath: %v", checkInErr.Error(), secretPath))
+		}
+		return entities.ManagedAccountSSHKey{}, err
+	}
+
+	sshKey.RequestId = checkout.RequestId
+	return sshKey, nil
+}
+
+// ManageAccountSSHPublicKeyFlow returns the public key of the SSH key of secretPath in authorized_keys format.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountSSHPublicKeyFlow(secretPath string, separator string) (string, error) {
+	sshKey, err := managedAccountObj.ManageAccountSSHKeyFlow(secretPath, separator)
+	if err != nil {
+		return "", err
+	}
+	return sshKey.PublicKey, nil
+}
+
+// ManageAccountSSHSignerFlow returns an ssh.Signer for the SSH key of secretPath.
+func (managedAccountObj *ManagedAccountstObj) ManageAccountSSHSignerFlow(secretPath string, separator string) (ssh.Signer, error) {
+	sshKey, err := managedAccountObj.ManageAccountSSHKeyFlow(secretPath, separator)
+	if err != nil {
+		return nil, err
+	}
+	return NewSSHSigner(sshKey)
+}
+
+// NewSSHSigner returns an ssh.Signer for sshKey, decrypting the key with its passphrase when set.
+func NewSSHSigner(sshKey entities.ManagedAccountSSHKey) (ssh.Signer, error) {
+	if sshKey.PrivateKey == "" {
+		return nil, errors.New("empty SSH private key")
+	}
+
+	var signer ssh.Signer
+	var err error
+	if sshKey.Passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(sshKey.PrivateKey), []byte(sshKey.Passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(sshKey.PrivateKey))
+	}
+	if err != nil {
+		// The parse errors do not carry key material.
+		return nil, fmt.Errorf("invalid SSH private key of %v/%v: %w", sshKey.SystemName, sshKey.AccountName, err)
+	}
+	return signer, nil
+}
+
+// sshKey retrieves the SSH key of the request opened by checkout.
+func (managedAccountObj *ManagedAccountstObj) sshKey(checkout managedAccountCheckout) (entities.ManagedAccountSSHKey, error) {
+	sshKey := entities.ManagedAccountSSHKey{
+		SystemName:  checkout.SystemName,
+		AccountName: checkout.AccountName,
+		SystemId:    checkout.SystemId,
+		AccountId:   checkout.AccountId,
+	}
+
+	privateKey, err := managedAccountObj.CredentialByRequestIdAndType(checkout.RequestId, CredentialTypeDssKey)
+	if err != nil {
+		return sshKey, err
+	}
+	if strings.TrimSpace(privateKey) == "" {
+		return sshKey, fmt.Errorf("managed account %v/%v has no SSH key", checkout.SystemName, checkout.AccountName)
+	}
+	sshKey.PrivateKey = privateKey
+
+	_, err = ssh.ParseRawPrivateKey([]byte(privateKey))
+	var passphraseMissingError *ssh.PassphraseMissingError
+	if errors.As(err, &passphraseMissingError) {
+		sshKey.Passphrase, err = managedAccountObj.CredentialByRequestIdAndType(checkout.RequestId, CredentialTypePassphrase)
+		if err != nil {
+			return sshKey, err
+		}
+	}
+
+	signer, err := NewSSHSigner(sshKey)
+	if err != nil {
+		return sshKey, err
+	}
+	sshKey.PublicKey = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+
+	return sshKey, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package managed_accounts implements functions to retrieve managed accounts
+// Unit tests for managed_accounts package.
+package managed_accounts
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"golang.org/x/crypto/ssh"
+)
+
+// newSSHKeyServer returns a server handing out privateKey and passphrase for account 10.
+func newSSHKeyServer(t *testing.T, privateKey string, passphrase string, checkIns *atomic.Int32) *httptest.Server {
+	quote := func(value string) []byte {
+		quoted, _ := json.Marshal(value)
+		return quoted
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ManagedAccounts":
+			_, _ = w.Write([]byte(`{"SystemId":1,"SystemName":"system01","AccountId":10,"AccountName":"account01"}`))
+		case "/Requests":
+			_, _ = w.Write([]byte(`124`))
+		case "/Credentials/124":
+			switch r.URL.Query().Get("type") {
+			case CredentialTypeDssKey:
+				_, _ = w.Write(quote(privateKey))
+			case CredentialTypePassphrase:
+				_, _ = w.Write(quote(passphrase))
+			default:
+				t.Errorf("Test case Failed, credential type %v", r.URL.Query().Get("type"))
+				http.NotFound(w, r)
+			}
+		case "/Requests/124/checkin":
+			checkIns.Add(1)
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestManageAccountSSHKeyFlow(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	sshPublicKey, _ := ssh.NewPublicKey(publicKey)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey)))
+
+	plainBlock, _ := ssh.MarshalPrivateKey(privateKey, "")
+	encryptedBlock, _ := ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte("key-passphrase"))
+
+	testCases := []struct {
+		name       string
+		privateKey string
+		passphrase string
+	}{
+		{"plain", string(pem.EncodeToMemory(plainBlock)), ""},
+		{"encrypted", string(pem.EncodeToMemory(encryptedBlock)), "key-passphrase"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			InitializeGlobalConfig()
+			var authenticate, _ = authentication.Authenticate(*authParams)
+			var checkIns atomic.Int32
+			server := newSSHKeyServer(t, testCase.privateKey, testCase.passphrase, &checkIns)
+			defer server.Close()
+
+			var logBuffer bytes.Buffer
+			logger := logging.NewRedactingLogger(logging.NewLogLogger(log.New(&logBuffer, "", 0)))
+
+			apiUrl, _ := url.Parse(server.URL)
+			authenticate.ApiUrl = *apiUrl
+			managedAccountObj, _ := NewManagedAccountObj(*authenticate, logger)
+
+			sshKey, err := managedAccountObj.ManageAccountSSHKeyFlow("system01/account01", "/")
+			if err != nil {
+				t.Fatalf("Test case Failed: %v", err)
+			}
+			if sshKey.PrivateKey != testCase.privateKey || sshKey.Passphrase != testCase.passphrase || sshKey.PublicKey != authorizedKey || sshKey.AccountId != 10 {
+				t.Errorf("Test case Failed %+v", sshKey)
+			}
+			if checkIns.Load() != 1 {
+				t.Errorf("Test case Failed, %v check-ins", checkIns.Load())
+			}
+
+			signer, err := managedAccountObj.ManageAccountSSHSignerFlow("system01/account01", "/")
+			if err != nil || !bytes.Equal(signer.PublicKey().Marshal(), sshPublicKey.Marshal()) {
+				t.Errorf("Test case Failed: %v", err)
+			}
+
+			// The key material is redacted, line by line too.
+			keyLine := strings.Split(testCase.privateKey, "\n")[1]
+			logger.Info("key " + testCase.privateKey + " line " + keyLine)
+			if strings.Contains(logBuffer.String(), keyLine) {
+				t.Errorf("Test case Failed, the log leaks the key: %v", logBuffer.String())
+			}
+		})
+	}
+}
+
+func TestManageAccountSSHKeyFlowWithoutKey(t *testing.T) {
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var checkIns atomic.Int32
+	server := newSSHKeyServer(t, "", "", &checkIns)
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	// The request is checked in even when there is no key.
+	if _, err := managedAccountObj.ManageAccountSSHPublicKeyFlow("system01/account01", "/"); err == nil || checkIns.Load() != 1 {
+		t.Errorf("Test case Failed: %v, %v check-ins", err, checkIns.Load())
+	}
+}
+
+func TestNewSSHSigner(t *testing.T) {
+	if _, err := NewSSHSigner(entities.ManagedAccountSSHKey{}); err == nil {
+		t.Error("Test case Failed, an empty key must fail")
+	}
+	if _, err := NewSSHSigner(entities.ManagedAccountSSHKey{PrivateKey: "not a key"}); err == nil {
+		t.Error("Test case Failed, an invalid key must fail")
+	}
+}
+
+func TestManageAccountSSHKeyCheckoutFlow(t *testing.T) {
+	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	block, _ := ssh.MarshalPrivateKey(privateKey, "")
+
+	InitializeGlobalConfig()
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var checkIns atomic.Int32
+	server := newSSHKeyServer(t, string(pem.EncodeToMemory(block)), "", &checkIns)
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL)
+	authenticate.ApiUrl = *apiUrl
+	managedAccountObj, _ := NewManagedAccountObj(*authenticate, zapLogger)
+
+	// The request stays open until the caller checks it in.
+	sshKey, err := managedAccountObj.ManageAccountSSHKeyCheckoutFlow("system01/account01", "/")
+	if err != nil || sshKey.RequestId != "124" || checkIns.Load() != 0 {
+		t.Fatalf("Test case Failed %v, %v, %v check-ins", sshKey.RequestId, err, checkIns.Load())
+	}
+	if err = managedAccountObj.ManageAccountCheckInFlow(sshKey.RequestId); err != nil || checkIns.Load() != 1 {
+		t.Errorf("Test case Failed: %v, %v check-ins", err, checkIns.Load())
+	}
+}
@@ -15,8 +15,6 @@ import (
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
-
-	backoff "github.com/cenkalti/backoff/v4"
 )
 
 type ManagedSystemObj struct {
@@ -211,10 +209,10 @@ func (ManagedSystemObj *ManagedSystemObj) createManagedSystem(method string, pat
 		ApiVersion:  ManagedSystemObj.authenticationObj.ApiVersion,
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = ManagedSystemObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, ManagedSystemObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = ManagedSystemObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, ManagedSystemObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.ManagedSystemResponseCreate{}, technicalError
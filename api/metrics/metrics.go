@@ -0,0 +1,148 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package metrics implements an optional Prometheus collector for client activity.
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultNamespace = "passwordsafe_client"
+
+// Collector implements prometheus.Collector, utils.RequestObserver, utils.AttemptObserver
+// and utils.CircuitObserver.
+// Register it with a prometheus.Registerer and attach it to the HTTP client
+// using HttpClientObj.SetRequestObserver before authenticating.
+type Collector struct {
+	requests       *prometheus.CounterVec
+	requestLatency *prometheus.HistogramVec
+	retries        *prometheus.CounterVec
+	attempts       *prometheus.HistogramVec
+	circuitState   prometheus.Gauge
+	circuitChanges *prometheus.CounterVec
+	activeSessions prometheus.Gauge
+
+	mu       sync.Mutex
+	sessions int
+}
+
+// NewCollector creates a Collector, metric names are prefixed with namespace
+// ("passwordsafe_client" when empty).
+func NewCollector(namespace string) *Collector {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of Password Safe API calls by method name and HTTP status code.",
+		}, []string{"method", "code"}),
+		requestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of Password Safe API calls by method name.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried Password Safe API calls by method name.",
+		}, []string{"method"}),
+		attempts: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_attempts",
+			Help:      "Number of attempts of Password Safe API calls by method name.",
+			Buckets:   []float64{1, 2, 3, 5, 8, 13},
+		}, []string{"method"}),
+		circuitState: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "circuit_state",
+			Help:      "State of the circuit breaker: 0 closed, 1 half-open, 2 open.",
+		}),
+		circuitChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "circuit_state_changes_total",
+			Help:      "Number of circuit breaker state changes by new state.",
+		}, []string{"state"}),
+		activeSessions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_sessions",
+			Help:      "Number of Password Safe sessions signed in and not yet signed out.",
+		}),
+	}
+}
+
+// Describe sends the descriptors of all metrics to ch.
+func (collector *Collector) Describe(ch chan<- *prometheus.Desc) {
+	collector.requests.Describe(ch)
+	collector.requestLatency.Describe(ch)
+	collector.retries.Describe(ch)
+	collector.attempts.Describe(ch)
+	collector.circuitState.Describe(ch)
+	collector.circuitChanges.Describe(ch)
+	collector.activeSessions.Describe(ch)
+}
+
+// Collect sends the current value of all metrics to ch.
+func (collector *Collector) Collect(ch chan<- prometheus.Metric) {
+	collector.requests.Collect(ch)
+	collector.requestLatency.Collect(ch)
+	collector.retries.Collect(ch)
+	collector.attempts.Collect(ch)
+	collector.circuitState.Collect(ch)
+	collector.circuitChanges.Collect(ch)
+	collector.activeSessions.Collect(ch)
+}
+
+// ObserveRequest counts the call, records its latency and tracks sessions
+// opened by SignAppin and closed by SignOut.
+func (collector *Collector) ObserveRequest(method string, statusCode int, elapsed time.Duration) {
+	collector.requests.WithLabelValues(method, strconv.Itoa(statusCode)).Inc()
+	collector.requestLatency.WithLabelValues(method).Observe(elapsed.Seconds())
+
+	if statusCode < 200 || statusCode >= 300 {
+		return
+	}
+
+	switch method {
+	case constants.SignAppin:
+		collector.setSessions(1)
+	case constants.SignOut:
+		collector.setSessions(-1)
+	}
+}
+
+// ObserveRetry counts a retry of method.
+func (collector *Collector) ObserveRetry(method string) {
+	collector.retries.WithLabelValues(method).Inc()
+}
+
+// ObserveAttempts records the number of attempts of a call of method.
+func (collector *Collector) ObserveAttempts(method string, attempts int) {
+	collector.attempts.WithLabelValues(method).Observe(float64(attempts))
+}
+
+// ObserveCircuitState records a state change of the circuit breaker.
+func (collector *Collector) ObserveCircuitState(state utils.CircuitState) {
+	collector.circuitState.Set(float64(state))
+	collector.circuitChanges.WithLabelValues(state.String()).Inc()
+}
+
+// setSessions adjusts the active sessions gauge, never going below zero.
+func (collector *Collector) setSessions(delta int) {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	collector.sessions += delta
+	if collector.sessions < 0 {
+		collector.sessions = 0
+	}
+	collector.activeSessions.Set(float64(collector.sessions))
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Unit tests for metrics package.
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+func newTestAuthentication(t *testing.T, serverURL string, collector *Collector) *authentication.AuthenticationObj {
+	t.Helper()
+
+	logger, _ := zap.NewDevelopment()
+	zapLogger := logging.NewZapLogger(logger)
+
+	httpClientObj, err := utils.GetHttpClient(5, false, "", "", zapLogger)
+	if err != nil {
+		t.Fatalf("failed to create http client: %v", err)
+	}
+	httpClientObj.SetRequestObserver(collector)
+
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.InitialInterval = 10 * time.Millisecond
+	backoffDefinition.MaxElapsedTime = 200 * time.Millisecond
+
+	authenticate, err := authentication.Authenticate(authentication.AuthenticationParametersObj{
+		HTTPClient:        *httpClientObj,
+		BackoffDefinition: backoffDefinition,
+		EndpointURL:       serverURL,
+		Logger:            zapLogger,
+	})
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+
+	apiUrl, _ := url.Parse(serverURL + "/")
+	authenticate.ApiUrl = *apiUrl
+	return authenticate
+}
+
+func TestCollectorCountsRequestsAndSessions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Auth/SignAppIn":
+			_, _ = w.Write([]byte(`{"UserId":1, "EmailAddress":"test@beyondtrust.com"}`))
+		case "/Auth/Signout":
+			_, _ = w.Write([]byte(``))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	collector := NewCollector("")
+	authenticate := newTestAuthentication(t, server.URL, collector)
+
+	if _, err := authenticate.SignAppin(server.URL+"/Auth/SignAppIn", "", "fake"); err != nil {
+		t.Fatalf("SignAppin failed: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.activeSessions); got != 1 {
+		t.Errorf("expected 1 active session, got %v", got)
+	}
+
+	if err := authenticate.SignOut(); err != nil {
+		t.Fatalf("SignOut failed: %v", err)
+	}
+
+	if got := testutil.ToFloat64(collector.activeSessions); got != 0 {
+		t.Errorf("expected 0 active sessions, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(collector.requests.WithLabelValues(constants.SignAppin, "200")); got != 1 {
+		t.Errorf("expected 1 SignAppin request, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(collector.requestLatency); got != 2 {
+		t.Errorf("expected latency series for 2 methods, got %v", got)
+	}
+}
+
+func TestCollectorCountsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	collector := NewCollector("")
+	authenticate := newTestAuthentication(t, server.URL, collector)
+
+	if err := authenticate.SignOut(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	requests := testutil.ToFloat64(collector.requests.WithLabelValues(constants.SignOut, "500"))
+	retries := testutil.ToFloat64(collector.retries.WithLabelValues(constants.SignOut))
+
+	if retries < 1 {
+		t.Errorf("expected at least one retry, got %v", retries)
+	}
+
+	if requests != retries+1 {
+		t.Errorf("expected requests (%v) to be retries (%v) + 1", requests, retries)
+	}
+	if series := testutil.CollectAndCount(collector.attempts); series != 1 {
+		t.Errorf("expected the attempts of one method, got %v", series)
+	}
+}
+
+func TestCollectorTracksCircuitState(t *testing.T) {
+	collector := NewCollector("")
+
+	collector.ObserveCircuitState(utils.CircuitOpen)
+	collector.ObserveCircuitState(utils.CircuitHalfOpen)
+	collector.ObserveCircuitState(utils.CircuitOpen)
+
+	if got := testutil.ToFloat64(collector.circuitState); got != 2 {
+		t.Errorf("expected the open state 2, got %v", got)
+	}
+	if got := testutil.ToFloat64(collector.circuitChanges.WithLabelValues("open")); got != 2 {
+		t.Errorf("expected 2 changes to open, got %v", got)
+	}
+}
+
+func TestCollectorRegister(t *testing.T) {
+	collector := NewCollector("custom")
+	collector.setSessions(1)
+
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("failed to register collector: %v", err)
+	}
+
+	expected := `
+# HELP custom_active_sessions Number of Password Safe sessions signed in and not yet signed out.
+# TYPE custom_active_sessions gauge
+custom_active_sessions 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "custom_active_sessions"); err != nil {
+		t.Error(err)
+	}
+}
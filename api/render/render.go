@@ -0,0 +1,218 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package render renders text/template and .env files that reference Password Safe secrets.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// DefaultFileMode is the permission of rendered files, they contain secrets in clear text.
+const DefaultFileMode os.FileMode = 0600
+
+// SecretsGetter retrieves values for a list of paths, SecretObj and ManagedAccountstObj implement it.
+type SecretsGetter interface {
+	GetSecrets(secretPaths []string, separator string) (map[string]string, error)
+}
+
+// RendererObj renders templates using the functions:
+//
+//	{{ secret "folder/title" }}          Secrets Safe secret
+//	{{ managedAccount "system/account" }} managed account password
+//	{{ secret "folder/title" | envQuote }} value quoted for a .env file
+//
+// All references of a template are resolved in one GetSecrets call per source.
+type RendererObj struct {
+	log             logging.Logger
+	secrets         SecretsGetter
+	managedAccounts SecretsGetter
+	separator       string
+}
+
+// NewRendererObj creates a renderer, secrets or managedAccounts may be nil when templates do not reference them.
+func NewRendererObj(secrets SecretsGetter, managedAccounts SecretsGetter, separator string, logger logging.Logger) (*RendererObj, error) {
+	if secrets == nil && managedAccounts == nil {
+		return nil, fmt.Errorf("a secrets or managed accounts getter is required")
+	}
+
+	if separator == "" {
+		separator = "/"
+	}
+
+	rendererObj := &RendererObj{
+		log:             logger,
+		secrets:         secrets,
+		managedAccounts: managedAccounts,
+		separator:       separator,
+	}
+	return rendererObj, nil
+}
+
+// resolver collects references during the first pass and serves the retrieved values in the second.
+type resolver struct {
+	getter    SecretsGetter
+	kind      string
+	separator string
+	paths     []string
+	seen      map[string]bool
+	values    map[string]string
+	resolved  bool
+}
+
+func newResolver(getter SecretsGetter, kind string, separator string) *resolver {
+	return &resolver{getter: getter, kind: kind, separator: separator, seen: map[string]bool{}, values: map[string]string{}}
+}
+
+// lookup records path while collecting, and returns its value once resolved.
+func (r *resolver) lookup(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if r.getter == nil {
+		return "", fmt.Errorf("%v %v can not be resolved, no %v getter configured", r.kind, path, r.kind)
+	}
+
+	if !r.resolved {
+		if !r.seen[path] {
+			r.seen[path] = true
+			r.paths = append(r.paths, path)
+		}
+		return "", nil
+	}
+
+	value, ok := r.values[path]
+	if !ok {
+		// only reachable when a branch of the template depends on a secret value.
+		values, err := r.getter.GetSecrets([]string{path}, r.separator)
+		if err != nil {
+			return "", err
+		}
+		if value, ok = values[path]; !ok {
+			return "", utils.NewNotFoundError("%v %v was not found", r.kind, path)
+		}
+		r.values[path] = value
+	}
+	return value, nil
+}
+
+// resolve retrieves every collected path in one call.
+func (r *resolver) resolve() error {
+	r.resolved = true
+	if len(r.paths) == 0 {
+		return nil
+	}
+
+	values, err := r.getter.GetSecrets(r.paths, r.separator)
+	for _, path := range r.paths {
+		if _, ok := values[path]; !ok {
+			if err == nil {
+				err = utils.NewNotFoundError("%v %v was not found", r.kind, path)
+			}
+			return err
+		}
+	}
+	r.values = values
+	return nil
+}
+
+// Render executes templateText and returns the output. Nothing is returned unless
+// every referenced secret was retrieved.
+func (rendererObj *RendererObj) Render(name string, templateText string) (string, error) {
+	secrets := newResolver(rendererObj.secrets, "secret", rendererObj.separator)
+	managedAccounts := newResolver(rendererObj.managedAccounts, "managed account", rendererObj.separator)
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"secret":         secrets.lookup,
+		"managedAccount": managedAccounts.lookup,
+		"envQuote":       EnvQuote,
+	}).Parse(templateText)
+	if err != nil {
+		return "", err
+	}
+
+	// first pass collects the references.
+	if err = tmpl.Execute(&bytes.Buffer{}, nil); err != nil {
+		return "", err
+	}
+
+	if err = secrets.resolve(); err != nil {
+		return "", err
+	}
+	if err = managedAccounts.resolve(); err != nil {
+		return "", err
+	}
+
+	for _, values := range []map[string]string{secrets.values, managedAccounts.values} {
+		for _, value := range values {
+			logging.RegisterSecret(rendererObj.log, value)
+		}
+	}
+
+	var output bytes.Buffer
+	if err = tmpl.Execute(&output, nil); err != nil {
+		return "", err
+	}
+
+	rendererObj.log.Debug(fmt.Sprintf("rendered %v with %v secrets and %v managed accounts", name, len(secrets.paths), len(managedAccounts.paths)))
+	return output.String(), nil
+}
+
+// RenderFile renders the template at templatePath to outputPath with mode perm, DefaultFileMode when 0.
+func (rendererObj *RendererObj) RenderFile(templatePath string, outputPath string, perm os.FileMode) error {
+	templateText, err := os.ReadFile(templatePath)
+	if err != nil {
+		return err
+	}
+
+	output, err := rendererObj.Render(filepath.Base(templatePath), string(templateText))
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(outputPath, []byte(output), perm)
+}
+
+// WriteFile atomically replaces path with data. The file is written to a temporary
+// file in the same directory, restricted to perm (DefaultFileMode when 0) and renamed,
+// so readers never see partial content and the secrets are never world readable.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if perm == 0 {
+		perm = DefaultFileMode
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if err = tmpFile.Chmod(perm); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if _, err = tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// EnvQuote returns value double quoted and escaped for a .env file.
+func EnvQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, `$`, `\$`)
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package render renders text/template and .env files that reference Password Safe secrets.
+// Unit tests for render package.
+package render
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+)
+
+// fakeGetter returns the configured values and records every call.
+type fakeGetter struct {
+	values map[string]string
+	calls  [][]string
+}
+
+func (f *fakeGetter) GetSecrets(secretPaths []string, separator string) (map[string]string, error) {
+	f.calls = append(f.calls, secretPaths)
+	result := map[string]string{}
+	var err error
+	for _, path := range secretPaths {
+		if value, ok := f.values[path]; ok {
+			result[path] = value
+		} else {
+			err = errors.New("error - status code: 404")
+		}
+	}
+	return result, err
+}
+
+var zapLogger *logging.ZapLogger
+
+func init() {
+	logger, _ := zap.NewDevelopment()
+	zapLogger = logging.NewZapLogger(logger)
+}
+
+func TestRender(t *testing.T) {
+
+	secrets := &fakeGetter{values: map[string]string{"Safe/Folder/db-password": "p@ss\"word", "Safe/Folder/api-key": "key1"}}
+	managedAccounts := &fakeGetter{values: map[string]string{"sys/acct": "m1"}}
+	rendererObj, _ := NewRendererObj(secrets, managedAccounts, "/", zapLogger)
+
+	templateText := `DB_PASSWORD={{ secret "Safe/Folder/db-password" | envQuote }}
+API_KEY={{ secret "Safe/Folder/api-key" }}
+ADMIN={{ managedAccount "sys/acct" }}
+API_KEY_AGAIN={{ secret "Safe/Folder/api-key" }}
+`
+	output, err := rendererObj.Render("app.env", templateText)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	expected := `DB_PASSWORD="p@ss\"word"
+API_KEY=key1
+ADMIN=m1
+API_KEY_AGAIN=key1
+`
+	if output != expected {
+		t.Errorf("Test case Failed %q, %q", output, expected)
+	}
+
+	if len(secrets.calls) != 1 || len(secrets.calls[0]) != 2 || len(managedAccounts.calls) != 1 {
+		t.Errorf("references must be resolved in one batched call per source: %v %v", secrets.calls, managedAccounts.calls)
+	}
+}
+
+func TestRenderMissingSecret(t *testing.T) {
+
+	secrets := &fakeGetter{values: map[string]string{}}
+	rendererObj, _ := NewRendererObj(secrets, nil, "/", zapLogger)
+
+	if _, err := rendererObj.Render("missing", `{{ secret "Safe/missing" }}`); err == nil {
+		t.Errorf("expected an error for a missing secret")
+	}
+
+	if _, err := rendererObj.Render("no getter", `{{ managedAccount "sys/acct" }}`); err == nil {
+		t.Errorf("expected an error when no managed account getter is configured")
+	}
+}
+
+func TestRenderFile(t *testing.T) {
+
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "config.tmpl")
+	outputPath := filepath.Join(dir, "config.ini")
+
+	if err := os.WriteFile(templatePath, []byte(`password={{ secret "folder/title" }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rendererObj, _ := NewRendererObj(&fakeGetter{values: map[string]string{"folder/title": "value"}}, nil, "/", zapLogger)
+	if err := rendererObj.RenderFile(templatePath, outputPath, 0); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(outputPath)
+	if string(content) != "password=value" {
+		t.Errorf("Test case Failed %q", content)
+	}
+
+	info, _ := os.Stat(outputPath)
+	if info.Mode().Perm() != DefaultFileMode {
+		t.Errorf("rendered file permissions %v, expected %v", info.Mode().Perm(), DefaultFileMode)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("temporary file left behind: %v", entries)
+	}
+}
+
+func TestEnvQuote(t *testing.T) {
+	if quoted := EnvQuote("a\nb$c\\"); quoted != `"a\nb\$c\\"` {
+		t.Errorf("Test case Failed %v", quoted)
+	}
+}
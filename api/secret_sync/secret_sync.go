@@ -0,0 +1,336 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package secret_sync keeps Kubernetes Secrets in sync with Password Safe secrets and managed accounts.
+package secret_sync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// SourceType identifies where a mapped value is retrieved from.
+type SourceType string
+
+const (
+	// SourceSecret is a Secrets Safe secret, path is folder/title.
+	SourceSecret SourceType = "secret"
+	// SourceManagedAccount is a managed account, path is system/account.
+	SourceManagedAccount SourceType = "managed_account"
+)
+
+const (
+	// ManagedByLabel marks the Secrets created by the syncer.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the value of ManagedByLabel.
+	ManagedByValue = "passwordsafe-secret-sync"
+	// ChecksumAnnotation holds a checksum of the synced keys, workloads can use it to trigger a rollout.
+	ChecksumAnnotation = "passwordsafe.beyondtrust.com/checksum"
+)
+
+// ErrNotFound is returned by a SecretClient when the requested Secret does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Mapping maps one Password Safe secret or managed account to a key of a Kubernetes Secret.
+type Mapping struct {
+	Source    SourceType `validate:"required,oneof=secret managed_account"`
+	Path      string     `validate:"required"`
+	Namespace string     `validate:"required,max=63"`
+	Name      string     `validate:"required,max=253"`
+	Key       string     `validate:"required,max=253"`
+}
+
+// Secret is the subset of a Kubernetes Secret handled by the syncer.
+type Secret struct {
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	Data        map[string][]byte
+}
+
+// SecretsGetter retrieves values for a list of paths, SecretObj and ManagedAccountstObj implement it.
+type SecretsGetter interface {
+	GetSecrets(secretPaths []string, separator string) (map[string]string, error)
+}
+
+// SecretClient reads and writes Kubernetes Secrets, typically backed by client-go.
+type SecretClient interface {
+	Get(ctx context.Context, namespace string, name string) (*Secret, error)
+	Create(ctx context.Context, secret *Secret) error
+	Update(ctx context.Context, secret *Secret) error
+}
+
+// Action applied to a Kubernetes Secret.
+type Action string
+
+const (
+	ActionNone   Action = "none"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+)
+
+// Change is the difference between a desired Secret and the existing one.
+type Change struct {
+	Action      Action
+	Desired     *Secret
+	ChangedKeys []string
+}
+
+// SyncerObj builds desired Secrets from mappings and reconciles them.
+type SyncerObj struct {
+	log             logging.Logger
+	secrets         SecretsGetter
+	managedAccounts SecretsGetter
+	client          SecretClient
+	mappings        []Mapping
+	separator       string
+}
+
+// NewSyncerObj creates a syncer, secrets or managedAccounts may be nil when no mapping uses them.
+func NewSyncerObj(secrets SecretsGetter, managedAccounts SecretsGetter, client SecretClient, mappings []Mapping, separator string, logger logging.Logger) (*SyncerObj, error) {
+	if client == nil {
+		return nil, errors.New("secret client is required")
+	}
+
+	if separator == "" {
+		separator = "/"
+	}
+
+	for _, mapping := range mappings {
+		if err := utils.ValidateData(mapping); err != nil {
+			return nil, err
+		}
+		if mapping.Source == SourceSecret && secrets == nil {
+			return nil, fmt.Errorf("mapping %v requires a secrets getter", mapping.Path)
+		}
+		if mapping.Source == SourceManagedAccount && managedAccounts == nil {
+			return nil, fmt.Errorf("mapping %v requires a managed accounts getter", mapping.Path)
+		}
+	}
+
+	syncerObj := &SyncerObj{
+		log:             logger,
+		secrets:         secrets,
+		managedAccounts: managedAccounts,
+		client:          client,
+		mappings:        mappings,
+		separator:       separator,
+	}
+	return syncerObj, nil
+}
+
+// DesiredSecrets retrieves every mapped value and returns the desired Secrets sorted by namespace/name.
+// Keys whose value could not be retrieved are left out and the last retrieval error is returned.
+func (syncerObj *SyncerObj) DesiredSecrets() ([]*Secret, error) {
+	values := map[SourceType]map[string]string{}
+	var saveLastErr error
+
+	for source, getter := range map[SourceType]SecretsGetter{SourceSecret: syncerObj.secrets, SourceManagedAccount: syncerObj.managedAccounts} {
+		paths := syncerObj.pathsFor(source)
+		if len(paths) == 0 {
+			continue
+		}
+		retrieved, err := getter.GetSecrets(paths, syncerObj.separator)
+		if err != nil {
+			saveLastErr = err
+		}
+		values[source] = retrieved
+	}
+
+	secretsByName := map[string]*Secret{}
+	for _, mapping := range syncerObj.mappings {
+		value, ok := values[mapping.Source][mapping.Path]
+		if !ok {
+			syncerObj.log.Error(fmt.Sprintf("%v was not retrieved, key %v of %v/%v is not synced", mapping.Path, mapping.Key, mapping.Namespace, mapping.Name))
+			continue
+		}
+
+		id := mapping.Namespace + "/" + mapping.Name
+		secret, ok := secretsByName[id]
+		if !ok {
+			secret = &Secret{
+				Namespace:   mapping.Namespace,
+				Name:        mapping.Name,
+				Labels:      map[string]string{ManagedByLabel: ManagedByValue},
+				Annotations: map[string]string{},
+				Data:        map[string][]byte{},
+			}
+			secretsByName[id] = secret
+		}
+		secret.Data[mapping.Key] = []byte(value)
+	}
+
+	desired := make([]*Secret, 0, len(secretsByName))
+	for _, secret := range secretsByName {
+		secret.Annotations[ChecksumAnnotation] = Checksum(secret.Data)
+		desired = append(desired, secret)
+	}
+	sort.Slice(desired, func(i, j int) bool {
+		if desired[i].Namespace != desired[j].Namespace {
+			return desired[i].Namespace < desired[j].Namespace
+		}
+		return desired[i].Name < desired[j].Name
+	})
+
+	return desired, saveLastErr
+}
+
+// pathsFor returns the distinct paths mapped from source.
+func (syncerObj *SyncerObj) pathsFor(source SourceType) []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, mapping := range syncerObj.mappings {
+		if mapping.Source == source && !seen[mapping.Path] {
+			seen[mapping.Path] = true
+			paths = append(paths, mapping.Path)
+		}
+	}
+	return paths
+}
+
+// Diff compares desired with the existing Secret. Keys present only in the existing
+// Secret are kept, so synced keys can live next to keys managed by someone else.
+func Diff(desired *Secret, existing *Secret) Change {
+	if existing == nil {
+		return Change{Action: ActionCreate, Desired: desired, ChangedKeys: sortedKeys(desired.Data)}
+	}
+
+	merged := &Secret{
+		Namespace:   desired.Namespace,
+		Name:        desired.Name,
+		Labels:      mergeStrings(existing.Labels, desired.Labels),
+		Annotations: mergeStrings(existing.Annotations, desired.Annotations),
+		Data:        map[string][]byte{},
+	}
+	for key, value := range existing.Data {
+		merged.Data[key] = value
+	}
+
+	var changedKeys []string
+	for _, key := range sortedKeys(desired.Data) {
+		if current, ok := existing.Data[key]; !ok || !bytes.Equal(current, desired.Data[key]) {
+			changedKeys = append(changedKeys, key)
+		}
+		merged.Data[key] = desired.Data[key]
+	}
+
+	// the checksum only covers the keys retrieved in this pass, so it is refreshed on
+	// updates but does not force one when a value could not be retrieved.
+	if len(changedKeys) == 0 && existing.Labels[ManagedByLabel] == ManagedByValue {
+		return Change{Action: ActionNone, Desired: merged}
+	}
+	return Change{Action: ActionUpdate, Desired: merged, ChangedKeys: changedKeys}
+}
+
+// Plan retrieves the desired Secrets and diffs them against the existing ones without changing anything.
+func (syncerObj *SyncerObj) Plan(ctx context.Context) ([]Change, error) {
+	desired, saveLastErr := syncerObj.DesiredSecrets()
+
+	changes := make([]Change, 0, len(desired))
+	for _, secret := range desired {
+		existing, err := syncerObj.client.Get(ctx, secret.Namespace, secret.Name)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			saveLastErr = err
+			syncerObj.log.Error(fmt.Sprintf("getting secret %v/%v: %v", secret.Namespace, secret.Name, err))
+			continue
+		}
+		if errors.Is(err, ErrNotFound) {
+			existing = nil
+		}
+		changes = append(changes, Diff(secret, existing))
+	}
+
+	return changes, saveLastErr
+}
+
+// Reconcile plans and applies every create and update, it returns the changes that were applied.
+func (syncerObj *SyncerObj) Reconcile(ctx context.Context) ([]Change, error) {
+	changes, saveLastErr := syncerObj.Plan(ctx)
+
+	applied := make([]Change, 0, len(changes))
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			err = syncerObj.client.Create(ctx, change.Desired)
+		case ActionUpdate:
+			err = syncerObj.client.Update(ctx, change.Desired)
+		default:
+			continue
+		}
+
+		if err != nil {
+			saveLastErr = err
+			syncerObj.log.Error(fmt.Sprintf("%v secret %v/%v: %v", change.Action, change.Desired.Namespace, change.Desired.Name, err))
+			continue
+		}
+
+		syncerObj.log.Info(fmt.Sprintf("%v secret %v/%v, changed keys: %v", change.Action, change.Desired.Namespace, change.Desired.Name, change.ChangedKeys))
+		applied = append(applied, change)
+	}
+
+	return applied, saveLastErr
+}
+
+// Run reconciles immediately and then every interval until ctx is done. onReconcile, when not nil, receives the result of every pass.
+func (syncerObj *SyncerObj) Run(ctx context.Context, interval time.Duration, onReconcile func(applied []Change, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := syncerObj.Reconcile(ctx)
+		if onReconcile != nil {
+			onReconcile(applied, err)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Checksum returns a stable SHA-256 checksum of data.
+func Checksum(data map[string][]byte) string {
+	hash := sha256.New()
+	for _, key := range sortedKeys(data) {
+		fmt.Fprintf(hash, "%d:%s%d:", len(key), key, len(data[key]))
+		hash.Write(data[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// sortedKeys returns the keys of data in lexical order.
+func sortedKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeStrings returns a copy of base overwritten with override.
+func mergeStrings(base map[string]string, override map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
+	}
+	return merged
+}
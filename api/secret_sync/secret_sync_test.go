@@ -0,0 +1,200 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package secret_sync keeps Kubernetes Secrets in sync with Password Safe secrets and managed accounts.
+// Unit tests for secret_sync package.
+package secret_sync
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+)
+
+// fakeGetter returns the configured values and reports a missing path as an error.
+type fakeGetter struct {
+	values map[string]string
+	calls  int
+}
+
+func (f *fakeGetter) GetSecrets(secretPaths []string, separator string) (map[string]string, error) {
+	f.calls++
+	result := map[string]string{}
+	var err error
+	for _, path := range secretPaths {
+		if value, ok := f.values[path]; ok {
+			result[path] = value
+		} else {
+			err = errors.New("error - status code: 404")
+		}
+	}
+	return result, err
+}
+
+// fakeClient is an in-memory SecretClient.
+type fakeClient struct {
+	secrets map[string]*Secret
+	creates int
+	updates int
+}
+
+func newFakeClient(existing ...*Secret) *fakeClient {
+	client := &fakeClient{secrets: map[string]*Secret{}}
+	for _, secret := range existing {
+		client.secrets[secret.Namespace+"/"+secret.Name] = secret
+	}
+	return client
+}
+
+func (f *fakeClient) Get(ctx context.Context, namespace string, name string) (*Secret, error) {
+	secret, ok := f.secrets[namespace+"/"+name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return secret, nil
+}
+
+func (f *fakeClient) Create(ctx context.Context, secret *Secret) error {
+	f.creates++
+	f.secrets[secret.Namespace+"/"+secret.Name] = secret
+	return nil
+}
+
+func (f *fakeClient) Update(ctx context.Context, secret *Secret) error {
+	f.updates++
+	f.secrets[secret.Namespace+"/"+secret.Name] = secret
+	return nil
+}
+
+var zapLogger *logging.ZapLogger
+
+func init() {
+	logger, _ := zap.NewDevelopment()
+	zapLogger = logging.NewZapLogger(logger)
+}
+
+var mappings = []Mapping{
+	{Source: SourceSecret, Path: "folder1/db-password", Namespace: "app", Name: "db", Key: "password"},
+	{Source: SourceManagedAccount, Path: "system01/dbuser", Namespace: "app", Name: "db", Key: "admin-password"},
+	{Source: SourceSecret, Path: "folder1/api-key", Namespace: "web", Name: "api", Key: "key"},
+}
+
+func TestReconcile(t *testing.T) {
+
+	secrets := &fakeGetter{values: map[string]string{"folder1/db-password": "p1", "folder1/api-key": "k1"}}
+	managedAccounts := &fakeGetter{values: map[string]string{"system01/dbuser": "m1"}}
+	client := newFakeClient()
+
+	syncerObj, err := NewSyncerObj(secrets, managedAccounts, client, mappings, "/", zapLogger)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	applied, err := syncerObj.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if len(applied) != 2 || client.creates != 2 {
+		t.Fatalf("expected 2 creates, got %v", applied)
+	}
+
+	db := client.secrets["app/db"]
+	if string(db.Data["password"]) != "p1" || string(db.Data["admin-password"]) != "m1" || db.Labels[ManagedByLabel] != ManagedByValue {
+		t.Errorf("unexpected secret %+v", db)
+	}
+
+	// nothing changed, nothing is written.
+	applied, err = syncerObj.Reconcile(context.Background())
+	if err != nil || len(applied) != 0 || client.updates != 0 {
+		t.Errorf("expected no changes, got %v, %v", applied, err)
+	}
+
+	// a rotated password is detected and only that Secret is updated.
+	managedAccounts.values["system01/dbuser"] = "m2"
+	applied, err = syncerObj.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if len(applied) != 1 || applied[0].Action != ActionUpdate || !reflect.DeepEqual(applied[0].ChangedKeys, []string{"admin-password"}) {
+		t.Errorf("unexpected changes %+v", applied)
+	}
+	if string(client.secrets["app/db"].Data["admin-password"]) != "m2" {
+		t.Errorf("secret was not updated")
+	}
+}
+
+func TestReconcileKeepsUnmanagedKeysAndFailedValues(t *testing.T) {
+
+	existing := &Secret{
+		Namespace: "app",
+		Name:      "db",
+		Data:      map[string][]byte{"password": []byte("old"), "admin-password": []byte("m1"), "other": []byte("kept")},
+	}
+	client := newFakeClient(existing)
+
+	secrets := &fakeGetter{values: map[string]string{"folder1/db-password": "p1"}}
+	managedAccounts := &fakeGetter{values: map[string]string{}}
+
+	syncerObj, _ := NewSyncerObj(secrets, managedAccounts, client, mappings, "/", zapLogger)
+
+	changes, err := syncerObj.Plan(context.Background())
+	if err == nil {
+		t.Errorf("expected the retrieval error to be returned")
+	}
+	if client.updates != 0 || client.creates != 0 {
+		t.Errorf("plan must not write")
+	}
+	if len(changes) != 1 || changes[0].Action != ActionUpdate {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+
+	data := changes[0].Desired.Data
+	if string(data["password"]) != "p1" || string(data["admin-password"]) != "m1" || string(data["other"]) != "kept" {
+		t.Errorf("unexpected data %v", data)
+	}
+}
+
+func TestNewSyncerObjValidation(t *testing.T) {
+
+	client := newFakeClient()
+
+	if _, err := NewSyncerObj(&fakeGetter{}, &fakeGetter{}, client, []Mapping{{Source: "vault", Path: "a/b", Namespace: "ns", Name: "n", Key: "k"}}, "/", zapLogger); err == nil {
+		t.Errorf("expected invalid source error")
+	}
+
+	if _, err := NewSyncerObj(&fakeGetter{}, nil, client, mappings, "/", zapLogger); err == nil {
+		t.Errorf("expected missing managed accounts getter error")
+	}
+
+	if _, err := NewSyncerObj(&fakeGetter{}, nil, nil, nil, "/", zapLogger); err == nil {
+		t.Errorf("expected missing client error")
+	}
+}
+
+func TestRun(t *testing.T) {
+
+	secrets := &fakeGetter{values: map[string]string{"folder1/api-key": "k1"}}
+	syncerObj, _ := NewSyncerObj(secrets, nil, newFakeClient(), mappings[2:], "/", zapLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	passes := 0
+	syncerObj.Run(ctx, time.Millisecond, func(applied []Change, err error) {
+		passes++
+		if passes == 3 {
+			cancel()
+		}
+	})
+
+	if passes != 3 || secrets.calls != 3 {
+		t.Errorf("expected 3 reconcile passes, got %v", passes)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	if Checksum(map[string][]byte{"ab": []byte("c")}) == Checksum(map[string][]byte{"a": []byte("bc")}) {
+		t.Errorf("checksum must not collide on key/value boundaries")
+	}
+}
@@ -11,14 +11,14 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/audit"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/fallback"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
 	"github.com/google/uuid"
-
-	backoff "github.com/cenkalti/backoff/v4"
 )
 
 // SecretObj responsible for session requests.
@@ -27,6 +27,10 @@ type SecretObj struct {
 	authenticationObj      authentication.AuthenticationObj
 	maxFileSecretSizeBytes int
 	decrypt                bool
+	auditTrail             *audit.Trail
+	fallbackStore          *fallback.Store
+	// secretFlight shares the retrieval of a secret between concurrent callers.
+	secretFlight utils.SingleFlight[secretRetrieval]
 }
 
 // NewSecretObj creates secret obj
@@ -40,6 +44,17 @@ func NewSecretObj(authentication authentication.AuthenticationObj, logger loggin
 	return secretObj, nil
 }
 
+// SetAuditTrail records every secret read, file download, create and delete to trail.
+func (secretObj *SecretObj) SetAuditTrail(trail *audit.Trail) {
+	secretObj.auditTrail = trail
+}
+
+// SetFallbackStore saves the retrieved secrets to store and serves them from it
+// when Password Safe can not be reached, see fallback.Store.
+func (secretObj *SecretObj) SetFallbackStore(store *fallback.Store) {
+	secretObj.fallbackStore = store
+}
+
 // GetSecrets returns secret value for a path and title list.
 func (secretObj *SecretObj) GetSecrets(secretPaths []string, separator string) (map[string]string, error) {
 	return secretObj.GetSecretFlow(secretPaths, separator)
@@ -55,6 +70,13 @@ func (secretObj *SecretObj) GetSecret(secretPath string, separator string) (stri
 
 // GetFileSecret Get data of a file secret.
 func (secretObj *SecretObj) GetFileSecret(secret entities.Secret, secretPath string) (string, error) {
+	fileSecretContent, err := secretObj.getFileSecret(secret, secretPath)
+	secretObj.auditTrail.Record(audit.OperationDownload, secretPath, secret.Id, err)
+	return fileSecretContent, err
+}
+
+// getFileSecret returns the content of a file secret, it is not audited.
+func (secretObj *SecretObj) getFileSecret(secret entities.Secret, secretPath string) (string, error) {
 	fileSecretContent, err := secretObj.SecretGetFileSecret(secret.Id, "secrets-safe/secrets/")
 	if err != nil {
 		return "", err
@@ -64,9 +86,8 @@ func (secretObj *SecretObj) GetFileSecret(secret entities.Secret, secretPath str
 
 	if len(secretInBytes) > secretObj.maxFileSecretSizeBytes {
 		return "", fmt.Errorf("%v: %v %v %v %v", secretPath, "Secret file Size:", len(secretInBytes), "is greater than the maximum allowed size:", secretObj.maxFileSecretSizeBytes)
-	} else {
-		return fileSecretContent, nil
 	}
+	return fileSecretContent, nil
 }
 
 // GetGeneralSecret Get general data of a secret.
@@ -100,10 +121,17 @@ func (secretObj *SecretObj) SplitGetSecretPathAndSecretTitle(secretToRetrieve st
 
 // GetSecretFlow is responsible for creating a dictionary of secrets safe secret paths and secret key-value pairs.
 func (secretObj *SecretObj) GetSecretFlow(secretsToRetrieve []string, separator string) (map[string]string, error) {
+	secretResults, err := secretObj.GetSecretResults(secretsToRetrieve, separator)
+	return fallback.Values(secretResults), err
+}
+
+// GetSecretResults is GetSecretFlow reporting which values are served by the fallback store.
+func (secretObj *SecretObj) GetSecretResults(secretsToRetrieve []string, separator string) (map[string]fallback.Result, error) {
 
 	secretsToRetrieve = utils.ValidatePaths(secretsToRetrieve, false, separator, secretObj.log)
 	secretObj.log.Info(fmt.Sprintf("Retrieving %v Secrets", len(secretsToRetrieve)))
-	secretDictionary := make(map[string]string)
+	secretDictionary := make(map[string]fallback.Result)
+	retrievedSecrets := make(map[string]string)
 	var saveLastErr error = nil
 
 	if len(secretsToRetrieve) == 0 {
@@ -115,32 +143,71 @@ func (secretObj *SecretObj) GetSecretFlow(secretsToRetrieve []string, separator
 		secretPath, secretTitle := secretObj.SplitGetSecretPathAndSecretTitle(secretToRetrieve, separator)
 		entireSecretPath := secretPath + separator + secretTitle
 
-		secret, err := secretObj.GetGeneralSecret(secretPath, secretTitle, separator)
+		retrieval, shared, err := secretObj.secretFlight.Do(separator+"\x00"+entireSecretPath, func() (secretRetrieval, error) {
+			return secretObj.retrieveSecret(secretPath, secretTitle, separator)
+		})
+		if shared {
+			secretObj.log.Debug(fmt.Sprintf("secret %v retrieved by a concurrent identical request", entireSecretPath))
+		}
+		// Every caller is audited, also the ones sharing the retrieval of another.
+		secretObj.auditTrail.Record(retrieval.operation, entireSecretPath, retrieval.secretId, err)
 
 		if err != nil {
+			if result, ok := secretObj.fallbackStore.Fallback(fallback.KindSecret, secretToRetrieve, err); ok {
+				secretDictionary[secretToRetrieve] = result
+				continue
+			}
 			saveLastErr = err
-			secretObj.log.Error(err.Error())
+			secretObj.log.Error(err.Error() + "secretPath:" + entireSecretPath)
 			continue
 		}
 
-		// When secret type is FILE, it calls SecretGetFileSecret method.
-		if strings.ToUpper(secret.SecretType) == "FILE" {
-			fileSecretContent, err := secretObj.GetFileSecret(secret, entireSecretPath)
-			if err != nil {
-				saveLastErr = err
-				secretObj.log.Error(err.Error() + "secretPath:" + entireSecretPath)
-				continue
-			}
-			secretDictionary[secretToRetrieve] = fileSecretContent
+		retrievedSecrets[secretToRetrieve] = retrieval.value
+	}
 
-		} else {
-			secretDictionary[secretToRetrieve] = secret.Password
-		}
+	secretObj.fallbackStore.Save(fallback.KindSecret, retrievedSecrets)
+	for secretToRetrieve, secretValue := range retrievedSecrets {
+		secretDictionary[secretToRetrieve] = fallback.Result{Value: secretValue}
 	}
 
 	return secretDictionary, saveLastErr
 }
 
+// secretRetrieval is the result of retrieveSecret, operation and secretId are the ones to audit.
+type secretRetrieval struct {
+	value     string
+	secretId  string
+	operation audit.Operation
+}
+
+// retrieveSecret returns the value of the secret, the content of file secrets. It is not audited,
+// callers record the returned operation.
+func (secretObj *SecretObj) retrieveSecret(secretPath string, secretTitle string, separator string) (secretRetrieval, error) {
+	retrieval := secretRetrieval{operation: audit.OperationRead}
+
+	secret, err := secretObj.GetGeneralSecret(secretPath, secretTitle, separator)
+	if err != nil {
+		return retrieval, err
+	}
+	retrieval.secretId = secret.Id
+
+	// When secret type is FILE, it calls SecretGetFileSecret method.
+	if strings.ToUpper(secret.SecretType) == "FILE" {
+		retrieval.operation = audit.OperationDownload
+		fileSecretContent, err := secretObj.getFileSecret(secret, secretPath+separator+secretTitle)
+		if err != nil {
+			return retrieval, err
+		}
+		logging.RegisterSecret(secretObj.log, fileSecretContent)
+		retrieval.value = fileSecretContent
+		return retrieval, nil
+	}
+
+	logging.RegisterSecret(secretObj.log, secret.Password)
+	retrieval.value = secret.Password
+	return retrieval, nil
+}
+
 // SecretGetSecretByPath returns secret object for a specific path, title.
 func (secretObj *SecretObj) SecretGetSecretByPath(secretPath string, secretTitle string, separator string, endpointPath string) (entities.Secret, error) {
 
@@ -180,10 +247,10 @@ func (secretObj *SecretObj) SecretGetSecretByPath(secretPath string, secretTitle
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = secretObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, secretObj.authenticationObj.ExponentialBackOff, func() error {
 		body, scode, technicalError, businessError = secretObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, secretObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.Secret{}, technicalError
@@ -208,7 +275,7 @@ func (secretObj *SecretObj) SecretGetSecretByPath(secretPath string, secretTitle
 
 	if len(SecretObjectList) == 0 {
 		scode = 404
-		err = fmt.Errorf("error %v: StatusCode: %v ", "SecretGetSecretByPath, Secret was not found", scode)
+		err = utils.NewNotFoundError("error %v: StatusCode: %v ", "SecretGetSecretByPath, Secret was not found", scode)
 		return entities.Secret{}, err
 	}
 
@@ -239,10 +306,10 @@ func (secretObj *SecretObj) SecretGetFileSecret(secretId string, endpointPath st
 		ApiVersion:  secretObj.authenticationObj.ApiVersion,
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = secretObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, secretObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = secretObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, secretObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return "", technicalError
@@ -269,6 +336,19 @@ func (secretObj *SecretObj) SecretGetFileSecret(secretId string, endpointPath st
 // is selected here based on the authenticated API version — or a Config30/Config31
 // directly, which is passed through unchanged for backward compatibility.
 func (secretObj *SecretObj) CreateSecretFlow(folderTarget string, secretDetails interface{}) (entities.CreateSecretResponse, error) {
+	createResponse, err := secretObj.createSecretFlow(folderTarget, secretDetails)
+
+	auditPath := strings.TrimSpace(folderTarget)
+	if createResponse.Title != "" {
+		auditPath = auditPath + "/" + createResponse.Title
+	}
+	secretObj.auditTrail.Record(audit.OperationCreate, auditPath, createResponse.Id, err)
+
+	return createResponse, err
+}
+
+// createSecretFlow validates secretDetails, looks up folderTarget and creates the secret.
+func (secretObj *SecretObj) createSecretFlow(folderTarget string, secretDetails interface{}) (entities.CreateSecretResponse, error) {
 
 	var folder *entities.FolderResponse
 	var createResponse entities.CreateSecretResponse
@@ -309,7 +389,7 @@ func (secretObj *SecretObj) CreateSecretFlow(folderTarget string, secretDetails
 	}
 
 	if folder == nil {
-		return createResponse, fmt.Errorf("folder %v was not found in folder list", folderTarget)
+		return createResponse, utils.NewNotFoundError("folder %v was not found in folder list", folderTarget)
 	}
 
 	createResponse, err = secretObj.SecretCreateSecret(folder.Id, secretDetails)
@@ -512,10 +592,10 @@ func (secretObj *SecretObj) SecretCreateSecret(folderId string, secretDetails in
 		ApiVersion:  secretObj.authenticationObj.ApiVersion,
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = secretObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, secretObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = secretObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, secretObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.CreateSecretResponse{}, technicalError
@@ -645,7 +725,7 @@ func (secretObj *SecretObj) GetParentFolderId(folderTarget string) (string, erro
 	}
 
 	if parentFolder == nil {
-		return "", fmt.Errorf("folder %v was not found in folder list", folderTarget)
+		return "", utils.NewNotFoundError("folder %v was not found in folder list", folderTarget)
 	}
 
 	return parentFolder.Id, nil
@@ -653,6 +733,19 @@ func (secretObj *SecretObj) GetParentFolderId(folderTarget string) (string, erro
 
 // CreateFolderFlow is responsible for creating folders/safes in Password Safe.
 func (secretObj *SecretObj) CreateFolderFlow(folderTarget string, folderDetails entities.FolderDetails) (entities.CreateFolderResponse, error) {
+	createFolderResponse, err := secretObj.createFolderFlow(folderTarget, folderDetails)
+
+	folderId := ""
+	if err == nil {
+		folderId = createFolderResponse.Id.String()
+	}
+	secretObj.auditTrail.Record(audit.OperationCreate, folderDetails.Name, folderId, err)
+
+	return createFolderResponse, err
+}
+
+// createFolderFlow resolves the parent folder, validates folderDetails and creates the folder/safe.
+func (secretObj *SecretObj) createFolderFlow(folderTarget string, folderDetails entities.FolderDetails) (entities.CreateFolderResponse, error) {
 
 	var createFolderesponse entities.CreateFolderResponse
 	var err error
@@ -729,10 +822,10 @@ func (secretObj *SecretObj) SecretCreateFolder(folderDetails entities.FolderDeta
 		ApiVersion:  secretObj.authenticationObj.ApiVersion,
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = secretObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, secretObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = secretObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, secretObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.CreateFolderResponse{}, technicalError
@@ -765,7 +858,7 @@ func (secretObj *SecretObj) DeleteSecretById(secretID string) error {
 	urlBuilder := func(id string) string {
 		return secretObj.authenticationObj.ApiUrl.JoinPath("secrets-safe/secrets", id).String()
 	}
-	return utils.DeleteResourceByID(
+	err := utils.DeleteResourceByID(
 		secretID,
 		"secret",
 		constants.SecretDeleteSecret,
@@ -775,6 +868,8 @@ func (secretObj *SecretObj) DeleteSecretById(secretID string) error {
 		secretObj.authenticationObj.ExponentialBackOff,
 		secretObj.log,
 	)
+	secretObj.auditTrail.Record(audit.OperationDelete, "secrets-safe/secrets/"+secretID, secretID, err)
+	return err
 }
 
 // DeleteFolderById deletes a folder by its ID.
@@ -782,7 +877,7 @@ func (secretObj *SecretObj) DeleteFolderById(folderID string) error {
 	urlBuilder := func(id string) string {
 		return secretObj.authenticationObj.ApiUrl.JoinPath("secrets-safe/folders", id).String()
 	}
-	return utils.DeleteResourceByID(
+	err := utils.DeleteResourceByID(
 		folderID,
 		"folder",
 		constants.SecretDeleteFolder,
@@ -792,6 +887,8 @@ func (secretObj *SecretObj) DeleteFolderById(folderID string) error {
 		secretObj.authenticationObj.ExponentialBackOff,
 		secretObj.log,
 	)
+	secretObj.auditTrail.Record(audit.OperationDelete, "secrets-safe/folders/"+folderID, folderID, err)
+	return err
 }
 
 // DeleteSafeById deletes a safe by its ID.
@@ -799,7 +896,7 @@ func (secretObj *SecretObj) DeleteSafeById(safeID string) error {
 	urlBuilder := func(id string) string {
 		return secretObj.authenticationObj.ApiUrl.JoinPath("secrets-safe/safes", id).String()
 	}
-	return utils.DeleteResourceByID(
+	err := utils.DeleteResourceByID(
 		safeID,
 		"safe",
 		constants.SecretDeleteSafe,
@@ -809,6 +906,46 @@ func (secretObj *SecretObj) DeleteSafeById(safeID string) error {
 		secretObj.authenticationObj.ExponentialBackOff,
 		secretObj.log,
 	)
+	secretObj.auditTrail.Record(audit.OperationDelete, "secrets-safe/safes/"+safeID, safeID, err)
+	return err
+}
+
+// SecretGetSecretsListFlow lists the secrets under folderPath, all secrets when empty.
+// Passwords are never requested.
+func (secretObj *SecretObj) SecretGetSecretsListFlow(folderPath string, separator string) ([]entities.Secret, error) {
+	params := url.Values{}
+	if folderPath = strings.TrimSpace(folderPath); folderPath != "" {
+		params.Add("path", folderPath)
+		params.Add("separator", separator)
+	}
+	params.Add("decrypt", "false")
+
+	parsedUrl, err := url.Parse(secretObj.authenticationObj.ApiUrl.JoinPath("secrets-safe/secrets").String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
+	}
+	parsedUrl.RawQuery = params.Encode()
+
+	messageLog := fmt.Sprintf("%v %v", "GET", parsedUrl.String())
+	secretObj.log.Debug(messageLog)
+
+	callSecretSafeAPIObj := &entities.CallSecretSafeAPIObj{
+		Url:         parsedUrl.String(),
+		HttpMethod:  "GET",
+		Body:        bytes.Buffer{},
+		Method:      constants.SecretGetSecretsList,
+		AccessToken: "",
+		ApiKey:      "",
+		ContentType: "application/json",
+		ApiVersion:  "",
+	}
+
+	response, err := secretObj.authenticationObj.HttpClient.MakeRequest(callSecretSafeAPIObj, secretObj.authenticationObj.ExponentialBackOff)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeSecretListResponse(response)
 }
 
 // SearchSecretByTitleFlow calls Password Safe API endpoint to search secrets by title.
@@ -822,7 +959,7 @@ func (secretObj *SecretObj) SearchSecretByTitleFlow(secretTitle string) (entitie
 	if len(secretResponse) > 0 {
 		return secretResponse[0], nil
 	}
-	return entities.Secret{}, fmt.Errorf("secret was not found: %s", secretTitle)
+	return entities.Secret{}, utils.NewNotFoundError("secret was not found: %s", secretTitle)
 }
 
 // SearchSecretByTitle calls secrets-safe/secrets endpoint
@@ -870,5 +1007,9 @@ func (secretObj *SecretObj) SearchSecretByTitle(endpointPath string, title strin
 		return nil, err
 	}
 
+	for _, secret := range secretResponse {
+		logging.RegisterSecret(secretObj.log, secret.Password)
+	}
+
 	return secretResponse, nil
 }
@@ -4,16 +4,22 @@
 package secrets
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/audit"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/fallback"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
 	"github.com/google/uuid"
@@ -2233,3 +2239,191 @@ func TestSecretGetSecretByPathWithV32WrappedResponse(t *testing.T) {
 		t.Errorf("Test case Failed: got %+v", response)
 	}
 }
+
+func TestSecretFlowAuditTrail(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+
+		case "/secrets-safe/secrets":
+			if r.URL.Query().Get("title") == "missing" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, err := w.Write([]byte(`[{"SecretType": "TEXT", "Password": "credential_in_sub_3_password","Id": "9152f5b6-07d6-4955-175a-08db047219ce","Title": "title1"}]`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		case "/secrets-safe/secrets/9152f5b6-07d6-4955-175a-08db047219ce":
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	secretObj, _ := NewSecretObj(*authenticate, zapLogger, 4000, true)
+
+	var events []audit.Event
+	secretObj.SetAuditTrail(audit.NewTrail(audit.SinkFunc(func(event audit.Event) {
+		events = append(events, event)
+	}), "ci-pipeline"))
+
+	_, _ = secretObj.GetSecretFlow([]string{"folder1/title1", "folder1/missing"}, "/")
+	_ = secretObj.DeleteSecretById("9152f5b6-07d6-4955-175a-08db047219ce")
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 audit events, got %v", events)
+	}
+
+	read := events[0]
+	if read.Operation != audit.OperationRead || read.Outcome != audit.OutcomeSuccess || read.Path != "folder1/title1" || read.SecretID != "9152f5b6-07d6-4955-175a-08db047219ce" || read.Principal != "ci-pipeline" {
+		t.Errorf("unexpected read event: %+v", read)
+	}
+
+	if events[1].Outcome != audit.OutcomeFailure || events[1].Path != "folder1/missing" || events[1].Error == "" {
+		t.Errorf("unexpected failure event: %+v", events[1])
+	}
+
+	if events[2].Operation != audit.OperationDelete || events[2].Outcome != audit.OutcomeSuccess || events[2].Path != "secrets-safe/secrets/9152f5b6-07d6-4955-175a-08db047219ce" {
+		t.Errorf("unexpected delete event: %+v", events[2])
+	}
+}
+
+func TestSecretGetSecretsListFlow(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, err := w.Write([]byte(`[{"Id": "9152f5b6-07d6-4955-175a-08db047219ce","Title": "title1","SecretType": "TEXT"}]`))
+		if err != nil {
+			t.Error("Test case Failed")
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	secretObj, _ := NewSecretObj(*authenticate, zapLogger, 4000, true)
+
+	secretsList, err := secretObj.SecretGetSecretsListFlow("folder1/sub", "/")
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	if len(secretsList) != 1 || secretsList[0].Title != "title1" {
+		t.Errorf("Test case Failed %v", secretsList)
+	}
+
+	if query.Get("path") != "folder1/sub" || query.Get("decrypt") != "false" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
+
+func TestSecretFlowFallbackStore(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case unavailable.Load():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Query().Get("title") == "title1":
+			_, _ = w.Write([]byte(`[{"Password": "credential_password","Id": "9152f5b6-07d6-4955-175a-08db047219ce","Title": "title1"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	secretObj, _ := NewSecretObj(*authenticate, zapLogger, 4000, true)
+
+	store, err := fallback.NewStore(filepath.Join(t.TempDir(), "lkg.bin"), bytes.Repeat([]byte{1}, 32), time.Hour, zapLogger)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	secretObj.SetFallbackStore(store)
+
+	results, err := secretObj.GetSecretResults([]string{"folder1/title1", "folder1/title2"}, "/")
+	if err == nil || results["folder1/title1"].Value != "credential_password" || results["folder1/title1"].Stale {
+		t.Fatalf("Test case Failed %+v %v", results, err)
+	}
+
+	unavailable.Store(true)
+	results, err = secretObj.GetSecretResults([]string{"folder1/title1"}, "/")
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if result := results["folder1/title1"]; result.Value != "credential_password" || !result.Stale || result.SavedAt.IsZero() {
+		t.Errorf("Test case Failed %+v", result)
+	}
+
+	// Secrets never retrieved have no fallback.
+	if _, err = secretObj.GetSecret("folder1/title2", "/"); err == nil {
+		t.Error("Test case Failed, expected an error")
+	}
+}
+
+func TestSecretFlowSharesConcurrentRequests(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`[{"Password": "credential_password","Id": "9152f5b6-07d6-4955-175a-08db047219ce","Title": "title1"}]`))
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	secretObj, _ := NewSecretObj(*authenticate, zapLogger, 4000, true)
+
+	var events atomic.Int32
+	secretObj.SetAuditTrail(audit.NewTrail(audit.SinkFunc(func(event audit.Event) {
+		if event.Operation == audit.OperationRead && event.Path == "folder1/title1" && event.Outcome == audit.OutcomeSuccess {
+			events.Add(1)
+		}
+	}), "ci-pipeline"))
+
+	var waitGroup sync.WaitGroup
+	for range 10 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if secret, err := secretObj.GetSecret("folder1/title1", "/"); secret != "credential_password" || err != nil {
+				t.Errorf("Test case Failed %v, %v", secret, err)
+			}
+		}()
+	}
+
+	// Let the goroutines join the request in flight.
+	time.Sleep(200 * time.Millisecond)
+	close(release)
+	waitGroup.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Test case Failed, %v calls, expected 1", calls.Load())
+	}
+	// Every caller is audited, not only the one making the call.
+	if events.Load() != 10 {
+		t.Errorf("Test case Failed, %v audit events, expected 10", events.Load())
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package smart_rules implements functions to manage Smart Rules in Password Safe.
+package smart_rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+type SmartRuleObj struct {
+	log               logging.Logger
+	authenticationObj authentication.AuthenticationObj
+}
+
+var endpointPath = "SmartRules"
+
+// NewSmartRuleObj creates SmartRuleObj.
+func NewSmartRuleObj(authentication authentication.AuthenticationObj, logger logging.Logger) (*SmartRuleObj, error) {
+	smartRuleObj := &SmartRuleObj{
+		log:               logger,
+		authenticationObj: authentication,
+	}
+	return smartRuleObj, nil
+}
+
+// CreateManagedAccountSmartRuleFlow creates a Smart Rule selecting the managed account AccountID (SmartRules/FilterSingleAccount).
+func (smartRuleObj *SmartRuleObj) CreateManagedAccountSmartRuleFlow(smartRuleDetails entities.SmartRuleManagedAccountDetails) (entities.SmartRuleResponse, error) {
+	return smartRuleObj.createSmartRuleFlow("FilterSingleAccount", smartRuleDetails)
+}
+
+// CreateAssetAttributeSmartRuleFlow creates a Smart Rule selecting the assets with the attributes AttributeIDs (SmartRules/FilterAssetAttribute).
+func (smartRuleObj *SmartRuleObj) CreateAssetAttributeSmartRuleFlow(smartRuleDetails entities.SmartRuleAssetAttributeDetails) (entities.SmartRuleResponse, error) {
+	return smartRuleObj.createSmartRuleFlow("FilterAssetAttribute", smartRuleDetails)
+}
+
+// createSmartRuleFlow validates smartRuleDetails and creates the Smart Rule with the filter endpoint.
+func (smartRuleObj *SmartRuleObj) createSmartRuleFlow(filter string, smartRuleDetails interface{}) (entities.SmartRuleResponse, error) {
+
+	var smartRuleResponse entities.SmartRuleResponse
+
+	err := utils.ValidateData(smartRuleDetails)
+	if err != nil {
+		return smartRuleResponse, err
+	}
+
+	createSmartRuleUrl := smartRuleObj.authenticationObj.ApiUrl.JoinPath(endpointPath, filter).String()
+	response, err := utils.SendResource(
+		createSmartRuleUrl,
+		"POST",
+		smartRuleObj.authenticationObj.ApiVersion,
+		constants.CreateSmartRule,
+		smartRuleDetails,
+		&smartRuleObj.authenticationObj.HttpClient,
+		smartRuleObj.authenticationObj.ExponentialBackOff,
+		smartRuleObj.log,
+	)
+	if err != nil {
+		return smartRuleResponse, err
+	}
+
+	err = json.Unmarshal(response, &smartRuleResponse)
+	if err != nil {
+		return smartRuleResponse, err
+	}
+
+	return smartRuleResponse, nil
+}
+
+// GetSmartRulesListFlow returns the Smart Rules of Password Safe.
+func (smartRuleObj *SmartRuleObj) GetSmartRulesListFlow() ([]entities.SmartRuleResponse, error) {
+
+	var smartRulesResponse []entities.SmartRuleResponse
+
+	getSmartRulesUrl := smartRuleObj.authenticationObj.ApiUrl.JoinPath(endpointPath).String()
+	err := utils.GetResource(
+		getSmartRulesUrl,
+		smartRuleObj.authenticationObj.ApiVersion,
+		constants.GetSmartRulesList,
+		&smartRulesResponse,
+		&smartRuleObj.authenticationObj.HttpClient,
+		smartRuleObj.authenticationObj.ExponentialBackOff,
+		smartRuleObj.log,
+	)
+	if err != nil {
+		return smartRulesResponse, err
+	}
+
+	if len(smartRulesResponse) == 0 {
+		return smartRulesResponse, fmt.Errorf("empty smart rules list")
+	}
+
+	return smartRulesResponse, nil
+}
+
+// GetSmartRuleByIdFlow returns the Smart Rule smartRuleID.
+func (smartRuleObj *SmartRuleObj) GetSmartRuleByIdFlow(smartRuleID int) (entities.SmartRuleResponse, error) {
+
+	var smartRuleResponse entities.SmartRuleResponse
+
+	getSmartRuleUrl := smartRuleObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(smartRuleID)).String()
+	err := utils.GetResource(
+		getSmartRuleUrl,
+		smartRuleObj.authenticationObj.ApiVersion,
+		constants.GetSmartRule,
+		&smartRuleResponse,
+		&smartRuleObj.authenticationObj.HttpClient,
+		smartRuleObj.authenticationObj.ExponentialBackOff,
+		smartRuleObj.log,
+	)
+	if err != nil {
+		return smartRuleResponse, err
+	}
+
+	return smartRuleResponse, nil
+}
+
+// GetSmartRuleByTitleFlow returns the Smart Rule titled title, titles are compared case-insensitively.
+func (smartRuleObj *SmartRuleObj) GetSmartRuleByTitleFlow(title string) (entities.SmartRuleResponse, error) {
+	smartRules, err := smartRuleObj.GetSmartRulesListFlow()
+	if err != nil {
+		return entities.SmartRuleResponse{}, err
+	}
+
+	for _, smartRule := range smartRules {
+		if strings.EqualFold(smartRule.Title, title) {
+			return smartRule, nil
+		}
+	}
+	return entities.SmartRuleResponse{}, utils.NewNotFoundError("smart rule %v was not found", title)
+}
+
+// GetSmartRuleManagedAccountsFlow returns the managed accounts selected by the Smart Rule smartRuleID.
+func (smartRuleObj *SmartRuleObj) GetSmartRuleManagedAccountsFlow(smartRuleID int) ([]entities.ManagedAccount, error) {
+
+	var managedAccounts []entities.ManagedAccount
+
+	getManagedAccountsUrl := smartRuleObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(smartRuleID), "ManagedAccounts").String()
+	err := utils.GetResource(
+		getManagedAccountsUrl,
+		smartRuleObj.authenticationObj.ApiVersion,
+		constants.GetSmartRuleManagedAccountList,
+		&managedAccounts,
+		&smartRuleObj.authenticationObj.HttpClient,
+		smartRuleObj.authenticationObj.ExponentialBackOff,
+		smartRuleObj.log,
+	)
+	if err != nil {
+		return managedAccounts, err
+	}
+
+	return managedAccounts, nil
+}
+
+// DeleteSmartRuleById deletes a Smart Rule by its ID.
+func (smartRuleObj *SmartRuleObj) DeleteSmartRuleById(smartRuleID int) error {
+	urlBuilder := func(id string) string {
+		return smartRuleObj.authenticationObj.ApiUrl.JoinPath(endpointPath, id).String()
+	}
+	return utils.DeleteResourceByID(
+		strconv.Itoa(smartRuleID),
+		"smart rule",
+		constants.DeleteSmartRule,
+		urlBuilder,
+		false, // validate as integer
+		&smartRuleObj.authenticationObj.HttpClient,
+		smartRuleObj.authenticationObj.ExponentialBackOff,
+		smartRuleObj.log,
+	)
+}
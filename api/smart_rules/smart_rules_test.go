@@ -0,0 +1,170 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package smart_rules implements functions to manage Smart Rules in Password Safe
+// Unit tests for smart_rules package.
+package smart_rules
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	backoff "github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+)
+
+var authParams *authentication.AuthenticationParametersObj
+var zapLogger *logging.ZapLogger
+var apiVersion string = constants.ApiVersion31
+
+func InitializeGlobalConfig() {
+
+	logger, _ := zap.NewDevelopment()
+
+	zapLogger = logging.NewZapLogger(logger)
+
+	httpClientObj, _ := utils.GetHttpClient(5, false, "", "", zapLogger)
+
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.MaxElapsedTime = time.Second
+
+	authParams = &authentication.AuthenticationParametersObj{
+		HTTPClient:                 *httpClientObj,
+		BackoffDefinition:          backoffDefinition,
+		EndpointURL:                constants.FakeApiUrl,
+		APIVersion:                 apiVersion,
+		ClientID:                   constants.FakeClientId,
+		ClientSecret:               constants.FakeClientSecret,
+		ApiKey:                     "",
+		Logger:                     zapLogger,
+		RetryMaxElapsedTimeSeconds: 300,
+	}
+}
+
+func TestCreateSmartRuleFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /SmartRules/FilterSingleAccount", "POST /SmartRules/FilterAssetAttribute":
+			body, _ := io.ReadAll(r.Body)
+			bodies[r.URL.Path] = string(body)
+			_, err := w.Write([]byte(`{"SmartRuleID": 3, "Title": "Team A", "Category": "Teams", "RuleType": "ManagedAccount"}`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	smartRuleObj, _ := NewSmartRuleObj(*authenticate, zapLogger)
+
+	smartRule, err := smartRuleObj.CreateManagedAccountSmartRuleFlow(entities.SmartRuleManagedAccountDetails{AccountID: 10, Title: "Team A", Category: "Teams", ProcessImmediately: true})
+	if err != nil || smartRule.SmartRuleID != 3 {
+		t.Errorf("Test case Failed %v, %v", smartRule, err)
+	}
+	if bodies["/SmartRules/FilterSingleAccount"] != `{"AccountID":10,"Title":"Team A","Category":"Teams","ProcessImmediately":true}` {
+		t.Errorf("Test case Failed %v", bodies)
+	}
+
+	_, err = smartRuleObj.CreateAssetAttributeSmartRuleFlow(entities.SmartRuleAssetAttributeDetails{AttributeIDs: []int{5, 6}, Title: "Team A servers"})
+	if err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if bodies["/SmartRules/FilterAssetAttribute"] != `{"AttributeIDs":[5,6],"Title":"Team A servers","ProcessImmediately":false}` {
+		t.Errorf("Test case Failed %v", bodies)
+	}
+
+	// error case, The field 'Title' is required.
+	_, err = smartRuleObj.CreateManagedAccountSmartRuleFlow(entities.SmartRuleManagedAccountDetails{AccountID: 10})
+
+	expetedErrorMessage := "The field 'Title' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+
+	// error case, The field 'AttributeIDs' is required.
+	_, err = smartRuleObj.CreateAssetAttributeSmartRuleFlow(entities.SmartRuleAssetAttributeDetails{Title: "Team A servers"})
+
+	expetedErrorMessage = "The field 'AttributeIDs' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+}
+
+func TestSmartRuleFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /SmartRules":
+			_, _ = w.Write([]byte(`[{"SmartRuleID": 1, "Title": "All Managed Systems"}, {"SmartRuleID": 3, "Title": "Team A"}]`))
+		case "GET /SmartRules/3":
+			_, _ = w.Write([]byte(`{"SmartRuleID": 3, "Title": "Team A", "Status": 1}`))
+		case "GET /SmartRules/3/ManagedAccounts":
+			_, _ = w.Write([]byte(`[{"SystemId": 1, "SystemName": "system01", "AccountId": 10, "AccountName": "account01"}]`))
+		case "DELETE /SmartRules/3":
+			deleted = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	smartRuleObj, _ := NewSmartRuleObj(*authenticate, zapLogger)
+
+	smartRules, err := smartRuleObj.GetSmartRulesListFlow()
+	if err != nil || len(smartRules) != 2 {
+		t.Errorf("Test case Failed %v, %v", smartRules, err)
+	}
+
+	smartRule, err := smartRuleObj.GetSmartRuleByTitleFlow("team a")
+	if err != nil || smartRule.SmartRuleID != 3 {
+		t.Errorf("Test case Failed %v, %v", smartRule, err)
+	}
+	if _, err = smartRuleObj.GetSmartRuleByTitleFlow("Team B"); err == nil {
+		t.Error("Test case Failed, an unknown Smart Rule must fail")
+	}
+
+	smartRule, err = smartRuleObj.GetSmartRuleByIdFlow(3)
+	if err != nil || smartRule.Status != 1 {
+		t.Errorf("Test case Failed %v, %v", smartRule, err)
+	}
+
+	managedAccounts, err := smartRuleObj.GetSmartRuleManagedAccountsFlow(3)
+	if err != nil || len(managedAccounts) != 1 || managedAccounts[0].AccountId != 10 {
+		t.Errorf("Test case Failed %v, %v", managedAccounts, err)
+	}
+
+	if err = smartRuleObj.DeleteSmartRuleById(3); err != nil || !deleted {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if err = smartRuleObj.DeleteSmartRuleById(4); err == nil {
+		t.Error("Test case Failed, deleting an unknown Smart Rule must fail")
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package ssh_agent implements an SSH agent serving Password Safe managed account keys.
+package ssh_agent
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	managed_accounts "github.com/BeyondTrust/go-client-library-passwordsafe/api/managed_account"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// KeySource checks SSH keys out of Password Safe, implemented by managed_accounts.ManagedAccountstObj.
+type KeySource interface {
+	ManageAccountSSHKeyCheckoutFlow(secretPath string, separator string) (entities.ManagedAccountSSHKey, error)
+	ManageAccountCheckInFlow(requestId string) error
+}
+
+// Key is a managed account SSH key served by the agent.
+type Key struct {
+	// Path of the managed account, in the syntax of ManageAccountFlow.
+	Path string
+	// PublicKey lets the agent list the key before it is checked out, it is
+	// learned from the first checkout when nil.
+	PublicKey ssh.PublicKey
+}
+
+// managedKey is a key of the agent, signer and requestId are set while the key is checked out
+// and checkingOut while a checkout is in flight.
+type managedKey struct {
+	path        string
+	publicKey   ssh.PublicKey
+	signer      ssh.Signer
+	requestId   string
+	expiry      *time.Timer
+	checkingOut chan struct{}
+}
+
+// keyCheckIn is a request to check in once the lock is released.
+type keyCheckIn struct {
+	path      string
+	requestId string
+}
+
+var (
+	errLocked   = errors.New("agent is locked")
+	errReadOnly = errors.New("agent keys are managed in Password Safe and can not be added")
+)
+
+// Agent is an agent.ExtendedAgent serving managed account SSH keys. A key is checked out of
+// Password Safe the first time it signs, kept in memory only, and checked in once the lease
+// expires; the next signature checks it out again. Agent is goroutine-safe, the calls to
+// Password Safe are made without holding its lock.
+type Agent struct {
+	source    KeySource
+	separator string
+	lease     time.Duration
+	log       logging.Logger
+
+	mu         sync.Mutex
+	keys       []*managedKey
+	locked     bool
+	passphrase []byte
+}
+
+// NewAgent creates an agent serving keys from source. Keys are checked in lease after their
+// checkout, lease must not exceed the duration of the Password Safe requests
+// (managed_accounts.RequestDurationMinutes).
+func NewAgent(source KeySource, separator string, lease time.Duration, logger logging.Logger, keys ...Key) (*Agent, error) {
+	if lease <= 0 {
+		return nil, errors.New("agent lease must be greater than 0")
+	}
+	if requestDuration := managed_accounts.RequestDurationMinutes * time.Minute; lease > requestDuration {
+		return nil, fmt.Errorf("agent lease must not exceed the %v duration of the Password Safe requests", requestDuration)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("agent needs at least one key")
+	}
+
+	sshAgent := &Agent{source: source, separator: separator, lease: lease, log: logger}
+	for _, key := range keys {
+		if key.Path == "" {
+			return nil, errors.New("agent key path must not be empty")
+		}
+		sshAgent.keys = append(sshAgent.keys, &managedKey{path: key.Path, publicKey: key.PublicKey})
+	}
+	return sshAgent, nil
+}
+
+// List returns the keys of the agent, keys whose public key is unknown are checked out.
+func (sshAgent *Agent) List() ([]*agent.Key, error) {
+	sshAgent.mu.Lock()
+	defer sshAgent.mu.Unlock()
+
+	if sshAgent.locked {
+		return nil, nil
+	}
+
+	keys := []*agent.Key{}
+	for _, key := range sshAgent.keys {
+		if key.publicKey == nil {
+			if err := sshAgent.checkout(key); err != nil {
+				sshAgent.log.Error(fmt.Sprintf("%v secretsPath: %v", err.Error(), key.path))
+				continue
+			}
+		}
+		keys = append(keys, &agent.Key{Format: key.publicKey.Type(), Blob: key.publicKey.Marshal(), Comment: key.path})
+	}
+	return keys, nil
+}
+
+// Sign signs data with the key whose public key is publicKey.
+func (sshAgent *Agent) Sign(publicKey ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return sshAgent.SignWithFlags(publicKey, data, 0)
+}
+
+// SignWithFlags signs data with the key whose public key is publicKey, flags select the RSA signature algorithm.
+func (sshAgent *Agent) SignWithFlags(publicKey ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	sshAgent.mu.Lock()
+	defer sshAgent.mu.Unlock()
+
+	if sshAgent.locked {
+		return nil, errLocked
+	}
+
+	key, err := sshAgent.find(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = sshAgent.checkout(key); err != nil {
+		return nil, err
+	}
+
+	var algorithm string
+	switch {
+	case flags&agent.SignatureFlagRsaSha256 != 0:
+		algorithm = ssh.KeyAlgoRSASHA256
+	case flags&agent.SignatureFlagRsaSha512 != 0:
+		algorithm = ssh.KeyAlgoRSASHA512
+	}
+	if algorithm == "" {
+		return key.signer.Sign(rand.Reader, data)
+	}
+	algorithmSigner, ok := key.signer.(ssh.AlgorithmSigner)
+	if !ok {
+		return nil, fmt.Errorf("key of %v does not support signature algorithm %v", key.path, algorithm)
+	}
+	return algorithmSigner.SignWithAlgorithm(rand.Reader, data, algorithm)
+}
+
+// Add is not supported, the keys are managed in Password Safe.
+func (sshAgent *Agent) Add(agent.AddedKey) error {
+	return errReadOnly
+}
+
+// Remove checks the key whose public key is publicKey in before its lease expires.
+func (sshAgent *Agent) Remove(publicKey ssh.PublicKey) error {
+	sshAgent.mu.Lock()
+
+	if sshAgent.locked {
+		sshAgent.mu.Unlock()
+		return errLocked
+	}
+	for _, key := range sshAgent.keys {
+		if key.publicKey != nil && bytes.Equal(key.publicKey.Marshal(), publicKey.Marshal()) {
+			checkIns := sshAgent.release(key)
+			sshAgent.mu.Unlock()
+			sshAgent.checkIn(checkIns)
+			return nil
+		}
+	}
+	sshAgent.mu.Unlock()
+	return errors.New("key not found")
+}
+
+// RemoveAll checks all the keys in before their leases expire.
+func (sshAgent *Agent) RemoveAll() error {
+	sshAgent.mu.Lock()
+	if sshAgent.locked {
+		sshAgent.mu.Unlock()
+		return errLocked
+	}
+	checkIns := sshAgent.releaseAll()
+	sshAgent.mu.Unlock()
+
+	sshAgent.checkIn(checkIns)
+	return nil
+}
+
+// Lock checks all the keys in and refuses to list keys or sign until Unlock is called with passphrase.
+func (sshAgent *Agent) Lock(passphrase []byte) error {
+	sshAgent.mu.Lock()
+	if sshAgent.locked {
+		sshAgent.mu.Unlock()
+		return errLocked
+	}
+	checkIns := sshAgent.releaseAll()
+	sshAgent.locked = true
+	sshAgent.passphrase = bytes.Clone(passphrase)
+	sshAgent.mu.Unlock()
+
+	sshAgent.checkIn(checkIns)
+	return nil
+}
+
+// Unlock undoes Lock.
+func (sshAgent *Agent) Unlock(passphrase []byte) error {
+	sshAgent.mu.Lock()
+	defer sshAgent.mu.Unlock()
+
+	if !sshAgent.locked {
+		return errors.New("agent is not locked")
+	}
+	if subtle.ConstantTimeCompare(passphrase, sshAgent.passphrase) != 1 {
+		return errors.New("incorrect passphrase")
+	}
+	sshAgent.locked = false
+	sshAgent.passphrase = nil
+	return nil
+}
+
+// Signers checks all the keys out and returns their signers. The signers keep the
+// private keys after the leases expire, use Sign to hold them only for the lease.
+func (sshAgent *Agent) Signers() ([]ssh.Signer, error) {
+	sshAgent.mu.Lock()
+	defer sshAgent.mu.Unlock()
+
+	if sshAgent.locked {
+		return nil, errLocked
+	}
+
+	signers := []ssh.Signer{}
+	for _, key := range sshAgent.keys {
+		if err := sshAgent.checkout(key); err != nil {
+			return nil, err
+		}
+		signers = append(signers, key.signer)
+	}
+	return signers, nil
+}
+
+// Extension is not supported.
+func (sshAgent *Agent) Extension(extensionType string, contents []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
+
+// Close checks all the keys in, the agent checks them out again when used.
+func (sshAgent *Agent) Close() error {
+	sshAgent.mu.Lock()
+	checkIns := sshAgent.releaseAll()
+	sshAgent.mu.Unlock()
+
+	sshAgent.checkIn(checkIns)
+	return nil
+}
+
+// find returns the key whose public key is publicKey, checking out the keys whose public key is unknown.
+func (sshAgent *Agent) find(publicKey ssh.PublicKey) (*managedKey, error) {
+	blob := publicKey.Marshal()
+	for _, key := range sshAgent.keys {
+		if key.publicKey != nil && bytes.Equal(key.publicKey.Marshal(), blob) {
+			return key, nil
+		}
+	}
+	for _, key := range sshAgent.keys {
+		if key.publicKey != nil {
+			continue
+		}
+		if err := sshAgent.checkout(key); err != nil {
+			sshAgent.log.Error(fmt.Sprintf("%v secretsPath: %v", err.Error(), key.path))
+			continue
+		}
+		if bytes.Equal(key.publicKey.Marshal(), blob) {
+			return key, nil
+		}
+	}
+	return nil, errors.New("key not found")
+}
+
+// checkout checks key out unless it is, and schedules its check-in. Callers hold the lock, it is
+// released while Password Safe is called; concurrent checkouts of key wait for the one in flight.
+func (sshAgent *Agent) checkout(key *managedKey) error {
+	for key.checkingOut != nil {
+		checkingOut := key.checkingOut
+		sshAgent.mu.Unlock()
+		<-checkingOut
+		sshAgent.mu.Lock()
+	}
+	if sshAgent.locked {
+		return errLocked
+	}
+	if key.signer != nil {
+		return nil
+	}
+
+	checkingOut := make(chan struct{})
+	key.checkingOut = checkingOut
+	sshAgent.mu.Unlock()
+
+	sshKey, err := sshAgent.source.ManageAccountSSHKeyCheckoutFlow(key.path, sshAgent.separator)
+	var signer ssh.Signer
+	if err == nil {
+		signer, err = managed_accounts.NewSSHSigner(sshKey)
+		if err != nil {
+			sshAgent.checkIn([]keyCheckIn{{path: key.path, requestId: sshKey.RequestId}})
+		}
+	}
+
+	sshAgent.mu.Lock()
+	key.checkingOut = nil
+	close(checkingOut)
+	if err != nil {
+		return err
+	}
+	if sshAgent.locked {
+		// The agent was locked during the checkout, the key must not be kept.
+		sshAgent.mu.Unlock()
+		sshAgent.checkIn([]keyCheckIn{{path: key.path, requestId: sshKey.RequestId}})
+		sshAgent.mu.Lock()
+		return errLocked
+	}
+
+	if key.publicKey != nil && !bytes.Equal(key.publicKey.Marshal(), signer.PublicKey().Marshal()) {
+		sshAgent.log.Warn(fmt.Sprintf("the SSH key of %v changed in Password Safe", key.path))
+	}
+	key.publicKey = signer.PublicKey()
+	key.signer = signer
+	key.requestId = sshKey.RequestId
+
+	requestId := sshKey.RequestId
+	key.expiry = time.AfterFunc(sshAgent.lease, func() {
+		sshAgent.mu.Lock()
+		var checkIns []keyCheckIn
+		// The key may have been released and checked out again since.
+		if key.requestId == requestId {
+			checkIns = sshAgent.release(key)
+		}
+		sshAgent.mu.Unlock()
+
+		sshAgent.checkIn(checkIns)
+	})
+	sshAgent.log.Debug(fmt.Sprintf("checked out the SSH key of %v for %v", key.path, sshAgent.lease))
+	return nil
+}
+
+// release drops the private key of key from memory and returns the request to check in. Callers hold the lock.
+func (sshAgent *Agent) release(key *managedKey) []keyCheckIn {
+	if key.signer == nil {
+		return nil
+	}
+	key.expiry.Stop()
+	checkIns := []keyCheckIn{{path: key.path, requestId: key.requestId}}
+	key.signer = nil
+	key.requestId = ""
+	key.expiry = nil
+	return checkIns
+}
+
+// releaseAll releases every key and returns the requests to check in. Callers hold the lock.
+func (sshAgent *Agent) releaseAll() []keyCheckIn {
+	var checkIns []keyCheckIn
+	for _, key := range sshAgent.keys {
+		checkIns = append(checkIns, sshAgent.release(key)...)
+	}
+	return checkIns
+}
+
+// checkIn checks the requests in without holding the lock, errors are logged as the keys are dropped anyway.
+func (sshAgent *Agent) checkIn(checkIns []keyCheckIn) {
+	for _, checkIn := range checkIns {
+		if err := sshAgent.source.ManageAccountCheckInFlow(checkIn.requestId); err != nil {
+			sshAgent.log.Error(fmt.Sprintf("%v secretsPath: %v", err.Error(), checkIn.path))
+			continue
+		}
+		sshAgent.log.Debug(fmt.Sprintf("checked in the SSH key of %v", checkIn.path))
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package ssh_agent implements an SSH agent serving Password Safe managed account keys.
+// Unit tests for ssh_agent package.
+package ssh_agent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+var zapLogger = logging.NewZapLogger(zap.NewNop())
+
+// fakeKeySource hands out a fixed key per path and records the open requests.
+type fakeKeySource struct {
+	mu        sync.Mutex
+	keys      map[string]string
+	checkouts int
+	open      map[string]bool
+	failures  map[string]error
+	// gates holds the checkouts of a path until the channel is closed, it is set up before use.
+	gates map[string]chan struct{}
+}
+
+func newFakeKeySource(t *testing.T, paths ...string) (*fakeKeySource, map[string]ssh.PublicKey) {
+	source := &fakeKeySource{keys: map[string]string{}, open: map[string]bool{}, failures: map[string]error{}}
+	publicKeys := map[string]ssh.PublicKey{}
+	for _, path := range paths {
+		publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+		block, err := ssh.MarshalPrivateKey(privateKey, "")
+		if err != nil {
+			t.Fatalf("Test case Failed: %v", err)
+		}
+		source.keys[path] = string(pem.EncodeToMemory(block))
+		publicKeys[path], _ = ssh.NewPublicKey(publicKey)
+	}
+	return source, publicKeys
+}
+
+func (source *fakeKeySource) ManageAccountSSHKeyCheckoutFlow(secretPath string, separator string) (entities.ManagedAccountSSHKey, error) {
+	if gate := source.gates[secretPath]; gate != nil {
+		<-gate
+	}
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	if err := source.failures[secretPath]; err != nil {
+		return entities.ManagedAccountSSHKey{}, err
+	}
+	source.checkouts++
+	requestId := fmt.Sprint(source.checkouts)
+	source.open[requestId] = true
+	return entities.ManagedAccountSSHKey{PrivateKey: source.keys[secretPath], RequestId: requestId}, nil
+}
+
+func (source *fakeKeySource) ManageAccountCheckInFlow(requestId string) error {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	if !source.open[requestId] {
+		return fmt.Errorf("request %v is not open", requestId)
+	}
+	delete(source.open, requestId)
+	return nil
+}
+
+func (source *fakeKeySource) state() (int, int) {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	return source.checkouts, len(source.open)
+}
+
+func TestAgentLazyCheckout(t *testing.T) {
+	source, publicKeys := newFakeKeySource(t, "system01/deploy")
+	sshAgent, err := NewAgent(source, "/", 100*time.Millisecond, zapLogger, Key{Path: "system01/deploy", PublicKey: publicKeys["system01/deploy"]})
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	// Listing a key whose public key is known does not check it out.
+	keys, err := sshAgent.List()
+	if err != nil || len(keys) != 1 || keys[0].Comment != "system01/deploy" {
+		t.Fatalf("Test case Failed %v, %v", keys, err)
+	}
+	if checkouts, _ := source.state(); checkouts != 0 {
+		t.Errorf("Test case Failed, %v checkouts", checkouts)
+	}
+
+	for range 2 {
+		signature, err := sshAgent.Sign(publicKeys["system01/deploy"], []byte("data"))
+		if err != nil {
+			t.Fatalf("Test case Failed: %v", err)
+		}
+		if err = publicKeys["system01/deploy"].Verify([]byte("data"), signature); err != nil {
+			t.Errorf("Test case Failed: %v", err)
+		}
+	}
+	if checkouts, open := source.state(); checkouts != 1 || open != 1 {
+		t.Errorf("Test case Failed, %v checkouts and %v open requests", checkouts, open)
+	}
+
+	// The key is checked in once the lease expires and checked out again by the next signature.
+	time.Sleep(300 * time.Millisecond)
+	if checkouts, open := source.state(); checkouts != 1 || open != 0 {
+		t.Errorf("Test case Failed, %v checkouts and %v open requests", checkouts, open)
+	}
+	if _, err = sshAgent.Sign(publicKeys["system01/deploy"], []byte("data")); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if checkouts, open := source.state(); checkouts != 2 || open != 1 {
+		t.Errorf("Test case Failed, %v checkouts and %v open requests", checkouts, open)
+	}
+
+	if err = sshAgent.Close(); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if _, open := source.state(); open != 0 {
+		t.Errorf("Test case Failed, %v open requests", open)
+	}
+}
+
+func TestAgentOverConnection(t *testing.T) {
+	source, publicKeys := newFakeKeySource(t, "system01/deploy", "system01/backup", "system01/broken")
+	source.failures["system01/broken"] = errors.New("Password Safe unavailable")
+
+	sshAgent, _ := NewAgent(source, "/", time.Minute, zapLogger, Key{Path: "system01/deploy"}, Key{Path: "system01/backup"}, Key{Path: "system01/broken"})
+	defer func() { _ = sshAgent.Close() }()
+
+	serverConn, clientConn := net.Pipe()
+	defer func() { _ = clientConn.Close() }()
+	go func() { _ = agent.ServeAgent(sshAgent, serverConn) }()
+	client := agent.NewClient(clientConn)
+
+	// Keys whose public key is unknown are checked out to be listed, keys that fail are skipped.
+	keys, err := client.List()
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("Test case Failed %v, %v", keys, err)
+	}
+
+	signature, err := client.Sign(publicKeys["system01/backup"], []byte("data"))
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if err = publicKeys["system01/backup"].Verify([]byte("data"), signature); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	if err = client.Add(agent.AddedKey{}); err == nil {
+		t.Error("Test case Failed, keys can not be added")
+	}
+
+	if err = client.Remove(publicKeys["system01/deploy"]); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if checkouts, open := source.state(); checkouts != 2 || open != 1 {
+		t.Errorf("Test case Failed, %v checkouts and %v open requests", checkouts, open)
+	}
+}
+
+func TestAgentLock(t *testing.T) {
+	source, publicKeys := newFakeKeySource(t, "system01/deploy")
+	sshAgent, _ := NewAgent(source, "/", time.Minute, zapLogger, Key{Path: "system01/deploy"})
+
+	if _, err := sshAgent.Signers(); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	// Locking checks the keys in.
+	if err := sshAgent.Lock([]byte("passphrase")); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if _, open := source.state(); open != 0 {
+		t.Errorf("Test case Failed, %v open requests", open)
+	}
+	if keys, _ := sshAgent.List(); len(keys) != 0 {
+		t.Errorf("Test case Failed, a locked agent lists no keys: %v", keys)
+	}
+	if _, err := sshAgent.Sign(publicKeys["system01/deploy"], []byte("data")); err == nil {
+		t.Error("Test case Failed, a locked agent must not sign")
+	}
+
+	if err := sshAgent.Unlock([]byte("wrong")); err == nil {
+		t.Error("Test case Failed, a wrong passphrase must fail")
+	}
+	if err := sshAgent.Unlock([]byte("passphrase")); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if _, err := sshAgent.Sign(publicKeys["system01/deploy"], []byte("data")); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	_ = sshAgent.Close()
+}
+
+func TestNewAgentErrors(t *testing.T) {
+	source, _ := newFakeKeySource(t)
+	if _, err := NewAgent(source, "/", 0, zapLogger, Key{Path: "system01/deploy"}); err == nil {
+		t.Error("Test case Failed, a zero lease must fail")
+	}
+	if _, err := NewAgent(source, "/", time.Minute, zapLogger); err == nil {
+		t.Error("Test case Failed, an agent without keys must fail")
+	}
+	if _, err := NewAgent(source, "/", time.Minute, zapLogger, Key{}); err == nil {
+		t.Error("Test case Failed, an empty path must fail")
+	}
+	if _, err := NewAgent(source, "/", 6*time.Minute, zapLogger, Key{Path: "system01/deploy"}); err == nil {
+		t.Error("Test case Failed, a lease longer than the requests must fail")
+	}
+}
+
+func TestAgentCheckoutDoesNotBlock(t *testing.T) {
+	source, publicKeys := newFakeKeySource(t, "system01/deploy", "system01/backup")
+	gate := make(chan struct{})
+	source.gates = map[string]chan struct{}{"system01/deploy": gate}
+	sshAgent, _ := NewAgent(source, "/", time.Minute, zapLogger,
+		Key{Path: "system01/deploy", PublicKey: publicKeys["system01/deploy"]}, Key{Path: "system01/backup", PublicKey: publicKeys["system01/backup"]})
+
+	// Two signatures wait for the same checkout.
+	signed := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := sshAgent.Sign(publicKeys["system01/deploy"], []byte("data"))
+			signed <- err
+		}()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if keys, err := sshAgent.List(); err != nil || len(keys) != 2 {
+			done <- fmt.Errorf("unexpected keys %v, %v", keys, err)
+			return
+		}
+		_, err := sshAgent.Sign(publicKeys["system01/backup"], []byte("data"))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Test case Failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test case Failed, the agent is blocked by a checkout in flight")
+	}
+
+	close(gate)
+	for range 2 {
+		if err := <-signed; err != nil {
+			t.Errorf("Test case Failed: %v", err)
+		}
+	}
+	if checkouts, open := source.state(); checkouts != 2 || open != 2 {
+		t.Errorf("Test case Failed, %v checkouts and %v open requests", checkouts, open)
+	}
+	_ = sshAgent.Close()
+	if _, open := source.state(); open != 0 {
+		t.Errorf("Test case Failed, %v open requests", open)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package user_groups implements functions to manage user groups in Password Safe.
+package user_groups
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// Group types of the create flows.
+const (
+	GroupTypeBeyondInsight   = "BeyondInsight"
+	GroupTypeActiveDirectory = "ActiveDirectory"
+	GroupTypeLdap            = "LdapDirectory"
+)
+
+type UserGroupObj struct {
+	log               logging.Logger
+	authenticationObj authentication.AuthenticationObj
+}
+
+var endpointPath = "UserGroups"
+
+// NewUserGroupObj creates UserGroupObj.
+func NewUserGroupObj(authentication authentication.AuthenticationObj, logger logging.Logger) (*UserGroupObj, error) {
+	userGroupObj := &UserGroupObj{
+		log:               logger,
+		authenticationObj: authentication,
+	}
+	return userGroupObj, nil
+}
+
+// CreateBeyondInsightUserGroupFlow creates a BeyondInsight user group.
+func (userGroupObj *UserGroupObj) CreateBeyondInsightUserGroupFlow(userGroupDetails entities.UserGroupBeyondInsightDetails) (entities.UserGroupResponse, error) {
+	userGroupDetails.GroupType = GroupTypeBeyondInsight
+	return userGroupObj.createUserGroupFlow(userGroupDetails)
+}
+
+// CreateActiveDirectoryUserGroupFlow creates a user group from an Active Directory group.
+func (userGroupObj *UserGroupObj) CreateActiveDirectoryUserGroupFlow(userGroupDetails entities.UserGroupActiveDirectoryDetails) (entities.UserGroupResponse, error) {
+	userGroupDetails.GroupType = GroupTypeActiveDirectory
+	return userGroupObj.createUserGroupFlow(userGroupDetails)
+}
+
+// CreateLdapUserGroupFlow creates a user group from an LDAP directory group.
+func (userGroupObj *UserGroupObj) CreateLdapUserGroupFlow(userGroupDetails entities.UserGroupLdapDetails) (entities.UserGroupResponse, error) {
+	userGroupDetails.GroupType = GroupTypeLdap
+	return userGroupObj.createUserGroupFlow(userGroupDetails)
+}
+
+// createUserGroupFlow validates userGroupDetails and creates the user group.
+func (userGroupObj *UserGroupObj) createUserGroupFlow(userGroupDetails interface{}) (entities.UserGroupResponse, error) {
+
+	var userGroupResponse entities.UserGroupResponse
+
+	err := utils.ValidateData(userGroupDetails)
+	if err != nil {
+		return userGroupResponse, err
+	}
+
+	createUserGroupUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath).String()
+	response, err := userGroupObj.sendRequest(constants.CreateUserGroup, "POST", createUserGroupUrl, userGroupDetails)
+	if err != nil {
+		return userGroupResponse, err
+	}
+
+	err = json.Unmarshal(response, &userGroupResponse)
+	if err != nil {
+		return userGroupResponse, err
+	}
+
+	return userGroupResponse, nil
+}
+
+// GetUserGroupsListFlow returns the user groups of Password Safe.
+func (userGroupObj *UserGroupObj) GetUserGroupsListFlow() ([]entities.UserGroupResponse, error) {
+
+	var userGroupsResponse []entities.UserGroupResponse
+
+	getUserGroupsUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath).String()
+	err := userGroupObj.getList(constants.GetUserGroupsList, getUserGroupsUrl, &userGroupsResponse)
+	if err != nil {
+		return userGroupsResponse, err
+	}
+
+	if len(userGroupsResponse) == 0 {
+		return userGroupsResponse, fmt.Errorf("empty user groups list")
+	}
+
+	return userGroupsResponse, nil
+}
+
+// GetUserGroupByIdFlow returns the user group userGroupID.
+func (userGroupObj *UserGroupObj) GetUserGroupByIdFlow(userGroupID int) (entities.UserGroupResponse, error) {
+
+	var userGroupResponse entities.UserGroupResponse
+
+	getUserGroupUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(userGroupID)).String()
+	err := userGroupObj.getList(constants.GetUserGroup, getUserGroupUrl, &userGroupResponse)
+	if err != nil {
+		return userGroupResponse, err
+	}
+
+	return userGroupResponse, nil
+}
+
+// DeleteUserGroupById deletes a user group by its ID.
+func (userGroupObj *UserGroupObj) DeleteUserGroupById(userGroupID int) error {
+	urlBuilder := func(id string) string {
+		return userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, id).String()
+	}
+	return userGroupObj.deleteResource(userGroupID, constants.DeleteUserGroup, urlBuilder)
+}
+
+// GetUserGroupMembersFlow returns the users of the user group userGroupID.
+func (userGroupObj *UserGroupObj) GetUserGroupMembersFlow(userGroupID int) ([]entities.UserResponse, error) {
+
+	var usersResponse []entities.UserResponse
+
+	getMembersUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(userGroupID), "Users").String()
+	err := userGroupObj.getList(constants.GetUserGroupMembers, getMembersUrl, &usersResponse)
+	if err != nil {
+		return usersResponse, err
+	}
+
+	return usersResponse, nil
+}
+
+// AddUserToGroupFlow adds the user userID to the user group userGroupID.
+func (userGroupObj *UserGroupObj) AddUserToGroupFlow(userGroupID int, userID int) error {
+	addMemberUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath("Users", strconv.Itoa(userID), endpointPath, strconv.Itoa(userGroupID)).String()
+	_, err := userGroupObj.sendRequest(constants.AddUserGroupMember, "POST", addMemberUrl, nil)
+	return err
+}
+
+// RemoveUserFromGroupFlow removes the user userID from the user group userGroupID.
+func (userGroupObj *UserGroupObj) RemoveUserFromGroupFlow(userGroupID int, userID int) error {
+	urlBuilder := func(id string) string {
+		return userGroupObj.authenticationObj.ApiUrl.JoinPath("Users", strconv.Itoa(userID), endpointPath, id).String()
+	}
+	return userGroupObj.deleteResource(userGroupID, constants.RemoveUserGroupMember, urlBuilder)
+}
+
+// GetUserGroupPermissionsFlow returns the feature permissions of the user group userGroupID.
+func (userGroupObj *UserGroupObj) GetUserGroupPermissionsFlow(userGroupID int) ([]entities.UserGroupPermissionResponse, error) {
+
+	var permissionsResponse []entities.UserGroupPermissionResponse
+
+	getPermissionsUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(userGroupID), "Permissions").String()
+	err := userGroupObj.getList(constants.GetUserGroupPermissions, getPermissionsUrl, &permissionsResponse)
+	if err != nil {
+		return permissionsResponse, err
+	}
+
+	return permissionsResponse, nil
+}
+
+// SetUserGroupPermissionsFlow grants permissions to the user group userGroupID.
+func (userGroupObj *UserGroupObj) SetUserGroupPermissionsFlow(userGroupID int, permissions []entities.UserGroupPermission) error {
+	if len(permissions) == 0 {
+		return fmt.Errorf("permissions list must not be empty")
+	}
+	for _, permission := range permissions {
+		if err := utils.ValidateData(permission); err != nil {
+			return err
+		}
+	}
+
+	setPermissionsUrl := userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(userGroupID), "Permissions").String()
+	_, err := userGroupObj.sendRequest(constants.SetUserGroupPermissions, "POST", setPermissionsUrl, permissions)
+	return err
+}
+
+// DeleteUserGroupPermissions revokes all the permissions of the user group userGroupID.
+func (userGroupObj *UserGroupObj) DeleteUserGroupPermissions(userGroupID int) error {
+	urlBuilder := func(id string) string {
+		return userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, id, "Permissions").String()
+	}
+	return userGroupObj.deleteResource(userGroupID, constants.DeleteUserGroupPermissions, urlBuilder)
+}
+
+// GetSmartRuleRolesFlow returns the roles of the user group userGroupID on the Smart Rule smartRuleID.
+func (userGroupObj *UserGroupObj) GetSmartRuleRolesFlow(userGroupID int, smartRuleID int) ([]entities.SmartRuleRoleResponse, error) {
+
+	var rolesResponse []entities.SmartRuleRoleResponse
+
+	getRolesUrl := userGroupObj.smartRuleRolesUrl(strconv.Itoa(userGroupID), smartRuleID)
+	err := userGroupObj.getList(constants.GetSmartRuleRoles, getRolesUrl, &rolesResponse)
+	if err != nil {
+		return rolesResponse, err
+	}
+
+	return rolesResponse, nil
+}
+
+// SetSmartRuleRolesFlow replaces the roles of the user group userGroupID on the Smart Rule smartRuleID.
+func (userGroupObj *UserGroupObj) SetSmartRuleRolesFlow(userGroupID int, smartRuleID int, rolesDetails entities.SmartRuleRolesDetails) error {
+	err := utils.ValidateData(rolesDetails)
+	if err != nil {
+		return err
+	}
+
+	setRolesUrl := userGroupObj.smartRuleRolesUrl(strconv.Itoa(userGroupID), smartRuleID)
+	_, err = userGroupObj.sendRequest(constants.SetSmartRuleRoles, "POST", setRolesUrl, rolesDetails)
+	return err
+}
+
+// DeleteSmartRuleRoles revokes the roles of the user group userGroupID on the Smart Rule smartRuleID.
+func (userGroupObj *UserGroupObj) DeleteSmartRuleRoles(userGroupID int, smartRuleID int) error {
+	urlBuilder := func(id string) string {
+		return userGroupObj.smartRuleRolesUrl(id, smartRuleID)
+	}
+	return userGroupObj.deleteResource(userGroupID, constants.DeleteSmartRuleRoles, urlBuilder)
+}
+
+// smartRuleRolesUrl returns the UserGroups/{userGroupID}/SmartRules/{smartRuleID}/Roles url.
+func (userGroupObj *UserGroupObj) smartRuleRolesUrl(userGroupID string, smartRuleID int) string {
+	return userGroupObj.authenticationObj.ApiUrl.JoinPath(endpointPath, userGroupID, "SmartRules", strconv.Itoa(smartRuleID), "Roles").String()
+}
+
+// getList calls the GET endpoint url and unmarshals the response into result.
+func (userGroupObj *UserGroupObj) getList(method string, url string, result interface{}) error {
+	return utils.GetResource(
+		url,
+		userGroupObj.authenticationObj.ApiVersion,
+		method,
+		result,
+		&userGroupObj.authenticationObj.HttpClient,
+		userGroupObj.authenticationObj.ExponentialBackOff,
+		userGroupObj.log,
+	)
+}
+
+// sendRequest calls Password Safe API endpoint url with payload as json body, when set.
+func (userGroupObj *UserGroupObj) sendRequest(method string, httpMethod string, url string, payload interface{}) ([]byte, error) {
+	return utils.SendResource(
+		url,
+		httpMethod,
+		userGroupObj.authenticationObj.ApiVersion,
+		method,
+		payload,
+		&userGroupObj.authenticationObj.HttpClient,
+		userGroupObj.authenticationObj.ExponentialBackOff,
+		userGroupObj.log,
+	)
+}
+
+// deleteResource calls the DELETE endpoint built by urlBuilder from userGroupID.
+func (userGroupObj *UserGroupObj) deleteResource(userGroupID int, method string, urlBuilder func(id string) string) error {
+	return utils.DeleteResourceByID(
+		strconv.Itoa(userGroupID),
+		"user group",
+		method,
+		urlBuilder,
+		false, // validate as integer
+		&userGroupObj.authenticationObj.HttpClient,
+		userGroupObj.authenticationObj.ExponentialBackOff,
+		userGroupObj.log,
+	)
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package user_groups implements functions to manage user groups in Password Safe
+// Unit tests for user_groups package.
+package user_groups
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	backoff "github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+)
+
+var authParams *authentication.AuthenticationParametersObj
+var zapLogger *logging.ZapLogger
+var apiVersion string = constants.ApiVersion31
+
+func InitializeGlobalConfig() {
+
+	logger, _ := zap.NewDevelopment()
+
+	zapLogger = logging.NewZapLogger(logger)
+
+	httpClientObj, _ := utils.GetHttpClient(5, false, "", "", zapLogger)
+
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.MaxElapsedTime = time.Second
+
+	authParams = &authentication.AuthenticationParametersObj{
+		HTTPClient:                 *httpClientObj,
+		BackoffDefinition:          backoffDefinition,
+		EndpointURL:                constants.FakeApiUrl,
+		APIVersion:                 apiVersion,
+		ClientID:                   constants.FakeClientId,
+		ClientSecret:               constants.FakeClientSecret,
+		ApiKey:                     "",
+		Logger:                     zapLogger,
+		RetryMaxElapsedTimeSeconds: 300,
+	}
+}
+
+func TestCreateUserGroupFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/UserGroups":
+			var payload map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Errorf("Test case Failed: %v", err)
+			}
+			payloads = append(payloads, payload)
+			_, err := w.Write([]byte(`{"GroupID": 12, "Name": "Team A", "IsActive": true}`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	userGroupObj, _ := NewUserGroupObj(*authenticate, zapLogger)
+
+	userGroup, err := userGroupObj.CreateBeyondInsightUserGroupFlow(entities.UserGroupBeyondInsightDetails{
+		UserGroupDetailsBase: entities.UserGroupDetailsBase{
+			GroupName:       "Team A",
+			IsActive:        true,
+			SmartRuleAccess: []entities.UserGroupSmartRuleAccess{{SmartRuleID: 3, AccessLevelID: 1}},
+		},
+	})
+	if err != nil || userGroup.GroupID != 12 {
+		t.Errorf("Test case Failed %v, %v", userGroup, err)
+	}
+
+	_, err = userGroupObj.CreateActiveDirectoryUserGroupFlow(entities.UserGroupActiveDirectoryDetails{
+		UserGroupDetailsBase: entities.UserGroupDetailsBase{GroupName: "Team A"},
+		DomainName:           "corp.example.com",
+	})
+	if err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	_, err = userGroupObj.CreateLdapUserGroupFlow(entities.UserGroupLdapDetails{
+		UserGroupDetailsBase:   entities.UserGroupDetailsBase{GroupName: "Team A"},
+		GroupDistinguishedName: "CN=TeamA,OU=Groups,DC=example,DC=com",
+		HostName:               "ldap.example.com",
+		MembershipAttribute:    "member",
+		AccountAttribute:       "uid",
+	})
+	if err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	expectedGroupTypes := []string{GroupTypeBeyondInsight, GroupTypeActiveDirectory, GroupTypeLdap}
+	if len(payloads) != len(expectedGroupTypes) {
+		t.Fatalf("Test case Failed %v, %v", payloads, expectedGroupTypes)
+	}
+	for i := range expectedGroupTypes {
+		if payloads[i]["groupType"] != expectedGroupTypes[i] || payloads[i]["groupName"] != "Team A" {
+			t.Errorf("Test case Failed %v, %v", payloads[i], expectedGroupTypes[i])
+		}
+	}
+
+	// error case, the fields of the embedded details are validated too.
+	_, err = userGroupObj.CreateActiveDirectoryUserGroupFlow(entities.UserGroupActiveDirectoryDetails{DomainName: "corp.example.com"})
+
+	expetedErrorMessage := "The field 'GroupName' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+
+	// error case, Smart Rule access needs the Smart Rule.
+	_, err = userGroupObj.CreateBeyondInsightUserGroupFlow(entities.UserGroupBeyondInsightDetails{
+		UserGroupDetailsBase: entities.UserGroupDetailsBase{
+			GroupName:       "Team A",
+			SmartRuleAccess: []entities.UserGroupSmartRuleAccess{{AccessLevelID: 1}},
+		},
+	})
+
+	expetedErrorMessage = "The field 'SmartRuleID' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+}
+
+func TestUserGroupFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	calls := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls[r.Method+" "+r.URL.Path] = string(body)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /UserGroups":
+			_, _ = w.Write([]byte(`[{"GroupID": 12, "Name": "Team A"}, {"GroupID": 13, "Name": "Team B"}]`))
+		case "GET /UserGroups/12":
+			_, _ = w.Write([]byte(`{"GroupID": 12, "Name": "Team A", "GroupType": "BeyondInsight"}`))
+		case "GET /UserGroups/12/Users":
+			_, _ = w.Write([]byte(`[{"UserID": 7, "UserName": "jdoe"}]`))
+		case "GET /UserGroups/12/Permissions":
+			_, _ = w.Write([]byte(`[{"PermissionID": 1, "PermissionName": "Password Safe Account Management", "AccessLevelID": 2}]`))
+		case "GET /UserGroups/12/SmartRules/3/Roles":
+			_, _ = w.Write([]byte(`[{"RoleID": 1, "Name": "Requestor"}]`))
+		case "POST /Users/7/UserGroups/12", "POST /UserGroups/12/Permissions", "POST /UserGroups/12/SmartRules/3/Roles",
+			"DELETE /Users/7/UserGroups/12", "DELETE /UserGroups/12/Permissions", "DELETE /UserGroups/12/SmartRules/3/Roles", "DELETE /UserGroups/12":
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	userGroupObj, _ := NewUserGroupObj(*authenticate, zapLogger)
+
+	userGroups, err := userGroupObj.GetUserGroupsListFlow()
+	if err != nil || len(userGroups) != 2 {
+		t.Errorf("Test case Failed %v, %v", userGroups, err)
+	}
+
+	userGroup, err := userGroupObj.GetUserGroupByIdFlow(12)
+	if err != nil || userGroup.GroupType != GroupTypeBeyondInsight {
+		t.Errorf("Test case Failed %v, %v", userGroup, err)
+	}
+
+	if err = userGroupObj.AddUserToGroupFlow(12, 7); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	members, err := userGroupObj.GetUserGroupMembersFlow(12)
+	if err != nil || len(members) != 1 || members[0].UserID != 7 {
+		t.Errorf("Test case Failed %v, %v", members, err)
+	}
+	if err = userGroupObj.RemoveUserFromGroupFlow(12, 7); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	if err = userGroupObj.SetUserGroupPermissionsFlow(12, []entities.UserGroupPermission{{PermissionID: 1, AccessLevelID: 2}}); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if calls["POST /UserGroups/12/Permissions"] != `[{"PermissionID":1,"AccessLevelID":2}]` {
+		t.Errorf("Test case Failed %v", calls["POST /UserGroups/12/Permissions"])
+	}
+	permissions, err := userGroupObj.GetUserGroupPermissionsFlow(12)
+	if err != nil || len(permissions) != 1 || permissions[0].AccessLevelID != 2 {
+		t.Errorf("Test case Failed %v, %v", permissions, err)
+	}
+	if err = userGroupObj.SetUserGroupPermissionsFlow(12, nil); err == nil {
+		t.Error("Test case Failed, an empty permissions list must fail")
+	}
+	if err = userGroupObj.DeleteUserGroupPermissions(12); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	rolesDetails := entities.SmartRuleRolesDetails{Roles: []entities.SmartRuleRole{{RoleID: 1}}, AccessPolicyID: 4}
+	if err = userGroupObj.SetSmartRuleRolesFlow(12, 3, rolesDetails); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if calls["POST /UserGroups/12/SmartRules/3/Roles"] != `{"Roles":[{"RoleID":1}],"AccessPolicyID":4}` {
+		t.Errorf("Test case Failed %v", calls["POST /UserGroups/12/SmartRules/3/Roles"])
+	}
+	roles, err := userGroupObj.GetSmartRuleRolesFlow(12, 3)
+	if err != nil || len(roles) != 1 || roles[0].Name != "Requestor" {
+		t.Errorf("Test case Failed %v, %v", roles, err)
+	}
+	if err = userGroupObj.SetSmartRuleRolesFlow(12, 3, entities.SmartRuleRolesDetails{}); err == nil {
+		t.Error("Test case Failed, roles are required")
+	}
+	if err = userGroupObj.DeleteSmartRuleRoles(12, 3); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	if err = userGroupObj.DeleteUserGroupById(12); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if _, ok := calls["DELETE /UserGroups/12"]; !ok {
+		t.Errorf("Test case Failed %v", calls)
+	}
+}
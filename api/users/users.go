@@ -0,0 +1,167 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package users implements functions to manage users in Password Safe.
+package users
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// User types of the create flows.
+const (
+	UserTypeBeyondInsight   = "BeyondInsight"
+	UserTypeActiveDirectory = "ActiveDirectory"
+	UserTypeLdap            = "LdapDirectory"
+)
+
+type UserObj struct {
+	log               logging.Logger
+	authenticationObj authentication.AuthenticationObj
+}
+
+var endpointPath = "Users"
+
+// NewUserObj creates UserObj.
+func NewUserObj(authentication authentication.AuthenticationObj, logger logging.Logger) (*UserObj, error) {
+	userObj := &UserObj{
+		log:               logger,
+		authenticationObj: authentication,
+	}
+	return userObj, nil
+}
+
+// CreateBeyondInsightUserFlow creates a BeyondInsight user.
+func (userObj *UserObj) CreateBeyondInsightUserFlow(userDetails entities.UserBeyondInsightDetails) (entities.UserResponse, error) {
+	userDetails.UserType = UserTypeBeyondInsight
+	return userObj.createUserFlow(userDetails)
+}
+
+// CreateActiveDirectoryUserFlow creates a user from Active Directory.
+func (userObj *UserObj) CreateActiveDirectoryUserFlow(userDetails entities.UserActiveDirectoryDetails) (entities.UserResponse, error) {
+	userDetails.UserType = UserTypeActiveDirectory
+	return userObj.createUserFlow(userDetails)
+}
+
+// CreateLdapUserFlow creates a user from an LDAP directory.
+func (userObj *UserObj) CreateLdapUserFlow(userDetails entities.UserLdapDetails) (entities.UserResponse, error) {
+	userDetails.UserType = UserTypeLdap
+	return userObj.createUserFlow(userDetails)
+}
+
+// createUserFlow validates userDetails and creates the user.
+func (userObj *UserObj) createUserFlow(userDetails interface{}) (entities.UserResponse, error) {
+
+	var userResponse entities.UserResponse
+
+	err := utils.ValidateData(userDetails)
+	if err != nil {
+		return userResponse, err
+	}
+
+	userResponse, err = userObj.createUser(userDetails)
+	if err != nil {
+		return userResponse, err
+	}
+
+	return userResponse, nil
+}
+
+// createUser calls Password Safe API enpoint to create a user.
+func (userObj *UserObj) createUser(userDetails interface{}) (entities.UserResponse, error) {
+
+	var userResponse entities.UserResponse
+
+	createUserUrl := userObj.authenticationObj.ApiUrl.JoinPath(endpointPath).String()
+	response, err := utils.SendResource(
+		createUserUrl,
+		"POST",
+		userObj.authenticationObj.ApiVersion,
+		constants.CreateUser,
+		userDetails,
+		&userObj.authenticationObj.HttpClient,
+		userObj.authenticationObj.ExponentialBackOff,
+		userObj.log,
+	)
+	if err != nil {
+		return userResponse, err
+	}
+
+	err = json.Unmarshal(response, &userResponse)
+	if err != nil {
+		return userResponse, err
+	}
+
+	return userResponse, nil
+}
+
+// GetUsersListFlow returns the users of Password Safe.
+func (userObj *UserObj) GetUsersListFlow() ([]entities.UserResponse, error) {
+
+	var usersResponse []entities.UserResponse
+
+	getUsersUrl := userObj.authenticationObj.ApiUrl.JoinPath(endpointPath).String()
+	err := utils.GetResource(
+		getUsersUrl,
+		userObj.authenticationObj.ApiVersion,
+		constants.GetUsersList,
+		&usersResponse,
+		&userObj.authenticationObj.HttpClient,
+		userObj.authenticationObj.ExponentialBackOff,
+		userObj.log,
+	)
+	if err != nil {
+		return usersResponse, err
+	}
+
+	if len(usersResponse) == 0 {
+		return usersResponse, fmt.Errorf("empty users list")
+	}
+
+	return usersResponse, nil
+}
+
+// GetUserByIdFlow returns the user userID.
+func (userObj *UserObj) GetUserByIdFlow(userID int) (entities.UserResponse, error) {
+
+	var userResponse entities.UserResponse
+
+	getUserUrl := userObj.authenticationObj.ApiUrl.JoinPath(endpointPath, strconv.Itoa(userID)).String()
+	err := utils.GetResource(
+		getUserUrl,
+		userObj.authenticationObj.ApiVersion,
+		constants.GetUser,
+		&userResponse,
+		&userObj.authenticationObj.HttpClient,
+		userObj.authenticationObj.ExponentialBackOff,
+		userObj.log,
+	)
+	if err != nil {
+		return userResponse, err
+	}
+
+	return userResponse, nil
+}
+
+// DeleteUserById deletes a user by its ID.
+func (userObj *UserObj) DeleteUserById(userID int) error {
+	urlBuilder := func(id string) string {
+		return userObj.authenticationObj.ApiUrl.JoinPath(endpointPath, id).String()
+	}
+	return utils.DeleteResourceByID(
+		strconv.Itoa(userID),
+		"user",
+		constants.DeleteUser,
+		urlBuilder,
+		false, // validate as integer
+		&userObj.authenticationObj.HttpClient,
+		userObj.authenticationObj.ExponentialBackOff,
+		userObj.log,
+	)
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Package users implements functions to manage users in Password Safe
+// Unit tests for users package.
+package users
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+	backoff "github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+)
+
+var authParams *authentication.AuthenticationParametersObj
+var zapLogger *logging.ZapLogger
+var apiVersion string = constants.ApiVersion31
+
+func InitializeGlobalConfig() {
+
+	logger, _ := zap.NewDevelopment()
+
+	zapLogger = logging.NewZapLogger(logger)
+
+	httpClientObj, _ := utils.GetHttpClient(5, false, "", "", zapLogger)
+
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.MaxElapsedTime = time.Second
+
+	authParams = &authentication.AuthenticationParametersObj{
+		HTTPClient:                 *httpClientObj,
+		BackoffDefinition:          backoffDefinition,
+		EndpointURL:                constants.FakeApiUrl,
+		APIVersion:                 apiVersion,
+		ClientID:                   constants.FakeClientId,
+		ClientSecret:               constants.FakeClientSecret,
+		ApiKey:                     "",
+		Logger:                     zapLogger,
+		RetryMaxElapsedTimeSeconds: 300,
+	}
+}
+
+func TestCreateUserFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	var userTypes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/Users":
+			var payload map[string]interface{}
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Errorf("Test case Failed: %v", err)
+			}
+			userTypes = append(userTypes, payload["UserType"].(string))
+			_, err := w.Write([]byte(`{"UserID": 7, "UserName": "jdoe"}`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	userObj, _ := NewUserObj(*authenticate, zapLogger)
+
+	user, err := userObj.CreateBeyondInsightUserFlow(entities.UserBeyondInsightDetails{
+		UserName:     "jdoe",
+		FirstName:    "John",
+		EmailAddress: "jdoe@example.com",
+		Password:     "fake_password",
+	})
+	if err != nil || user.UserID != 7 {
+		t.Errorf("Test case Failed %v, %v", user, err)
+	}
+
+	_, err = userObj.CreateActiveDirectoryUserFlow(entities.UserActiveDirectoryDetails{UserName: "jdoe", DomainName: "corp.example.com"})
+	if err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	_, err = userObj.CreateLdapUserFlow(entities.UserLdapDetails{
+		HostName:             "ldap.example.com",
+		DistinguishedName:    "CN=jdoe,OU=Users,DC=example,DC=com",
+		AccountNameAttribute: "sAMAccountName",
+	})
+	if err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	expectedUserTypes := []string{UserTypeBeyondInsight, UserTypeActiveDirectory, UserTypeLdap}
+	if len(userTypes) != len(expectedUserTypes) {
+		t.Fatalf("Test case Failed %v, %v", userTypes, expectedUserTypes)
+	}
+	for i := range expectedUserTypes {
+		if userTypes[i] != expectedUserTypes[i] {
+			t.Errorf("Test case Failed %v, %v", userTypes[i], expectedUserTypes[i])
+		}
+	}
+
+	// error case, invalid details are not sent.
+	_, err = userObj.CreateBeyondInsightUserFlow(entities.UserBeyondInsightDetails{UserName: "jdoe"})
+
+	expetedErrorMessage := "The field 'FirstName' is required."
+
+	if err == nil || err.Error() != expetedErrorMessage {
+		t.Errorf("Test case Failed %v, %v", err, expetedErrorMessage)
+	}
+	if len(userTypes) != 3 {
+		t.Errorf("Test case Failed, %v calls", len(userTypes))
+	}
+}
+
+func TestGetUsersFlows(t *testing.T) {
+
+	InitializeGlobalConfig()
+
+	var authenticate, _ = authentication.Authenticate(*authParams)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/Users":
+			_, err := w.Write([]byte(`[{"UserID": 7, "UserName": "jdoe"}, {"UserID": 8, "UserName": "asmith"}]`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		case r.Method == "GET" && r.URL.Path == "/Users/8":
+			_, err := w.Write([]byte(`{"UserID": 8, "UserName": "asmith", "EmailAddress": "asmith@example.com"}`))
+			if err != nil {
+				t.Error("Test case Failed")
+			}
+
+		case r.Method == "DELETE" && r.URL.Path == "/Users/8":
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiUrl, _ := url.Parse(server.URL + "/")
+	authenticate.ApiUrl = *apiUrl
+	userObj, _ := NewUserObj(*authenticate, zapLogger)
+
+	users, err := userObj.GetUsersListFlow()
+	if err != nil || len(users) != 2 {
+		t.Errorf("Test case Failed %v, %v", users, err)
+	}
+
+	user, err := userObj.GetUserByIdFlow(8)
+	if err != nil || user.EmailAddress != "asmith@example.com" {
+		t.Errorf("Test case Failed %v, %v", user, err)
+	}
+
+	if err = userObj.DeleteUserById(8); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	if err = userObj.DeleteUserById(9); err == nil {
+		t.Error("Test case Failed, deleting an unknown user must fail")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// spkiPinPrefix is the optional prefix of pins, as in "sha256/<base64>".
+const spkiPinPrefix = "sha256/"
+
+// PinMismatchError is returned, wrapped in the request error, when none of the
+// public keys presented by the server match the configured pins.
+type PinMismatchError struct {
+	Host string
+	// Presented are the pins of the certificates presented by the server.
+	Presented []string
+}
+
+func (e *PinMismatchError) Error() string {
+	host := e.Host
+	if host == "" {
+		host = "server"
+	}
+	return fmt.Sprintf("certificate pin mismatch for %v: none of the presented public keys (%v) match the configured pins", host, strings.Join(e.Presented, ", "))
+}
+
+// SPKIPin returns the pin of a certificate: the base64 encoded SHA-256 of its
+// SubjectPublicKeyInfo, with the "sha256/" prefix.
+func SPKIPin(certificate *x509.Certificate) string {
+	sum := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+	return spkiPinPrefix + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// parseSPKIPin validates pin and returns it with the "sha256/" prefix.
+func parseSPKIPin(pin string) (string, error) {
+	encoded := strings.TrimPrefix(strings.TrimSpace(pin), spkiPinPrefix)
+	sum, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sum) != sha256.Size {
+		return "", fmt.Errorf("invalid certificate pin %q, expected the base64 encoded SHA-256 of a public key", pin)
+	}
+	return spkiPinPrefix + encoded, nil
+}
+
+// WithSPKIPins accepts a server only when one of its certificates has one of the
+// public key pins, see SPKIPin. With verifyCa the pins are checked against the
+// verified chains so a CA or intermediate key can be pinned, without it only the
+// server certificate is checked. Several pin options add to the same set.
+func WithSPKIPins(pins ...string) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if len(pins) == 0 {
+			return errors.New("at least one certificate pin is required")
+		}
+		if settings.pins == nil {
+			settings.pins = map[string]bool{}
+		}
+		for _, pin := range pins {
+			parsedPin, err := parseSPKIPin(pin)
+			if err != nil {
+				return err
+			}
+			settings.pins[parsedPin] = true
+		}
+		return nil
+	}
+}
+
+// WithRootCAs trusts the certificates of pool instead of the system roots.
+func WithRootCAs(pool *x509.CertPool) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if pool == nil {
+			return errors.New("root CAs pool must not be nil")
+		}
+		settings.rootCAs = pool
+		return nil
+	}
+}
+
+// WithVerifyConnection adds a callback run after the certificate verification and
+// the pin check, an error aborts the connection.
+func WithVerifyConnection(verifyConnection func(state tls.ConnectionState) error) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.verifyConnections = append(settings.verifyConnections, verifyConnection)
+		return nil
+	}
+}
+
+// verifyConnection returns the tls.Config VerifyConnection callback for settings, nil when there is nothing to check.
+func (settings *httpClientSettings) verifyConnection() func(state tls.ConnectionState) error {
+	if len(settings.pins) == 0 && len(settings.verifyConnections) == 0 {
+		return nil
+	}
+
+	pins := settings.pins
+	verifiedChains := settings.verifyCa
+	callbacks := settings.verifyConnections
+
+	return func(state tls.ConnectionState) error {
+		if len(pins) > 0 {
+			if err := checkSPKIPins(state, pins, verifiedChains); err != nil {
+				return err
+			}
+		}
+		for _, callback := range callbacks {
+			if err := callback(state); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// checkSPKIPins checks the certificates of the verified chains, or only the server
+// certificate when the chain was not verified, against pins.
+func checkSPKIPins(state tls.ConnectionState, pins map[string]bool, verifiedChains bool) error {
+	var certificates []*x509.Certificate
+	if verifiedChains {
+		for _, chain := range state.VerifiedChains {
+			certificates = append(certificates, chain...)
+		}
+	} else if len(state.PeerCertificates) > 0 {
+		certificates = state.PeerCertificates[:1]
+	}
+
+	presented := make([]string, 0, len(certificates))
+	for _, certificate := range certificates {
+		pin := SPKIPin(certificate)
+		if pins[pin] {
+			return nil
+		}
+		presented = append(presented, pin)
+	}
+	return &PinMismatchError{Host: state.ServerName, Presented: presented}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+// Unit tests for utils package.
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+)
+
+// otherPin is a valid pin that matches no certificate.
+var otherPin = "sha256/" + base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+func newPinningTestServer(t *testing.T) (*httptest.Server, *x509.CertPool) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	t.Cleanup(server.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	return server, pool
+}
+
+func TestSPKIPins(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+	server, pool := newPinningTestServer(t)
+	serverPin := SPKIPin(server.Certificate())
+
+	testCases := []struct {
+		name     string
+		options  []HttpClientOption
+		mismatch bool
+	}{
+		{"verified chain match", []HttpClientOption{WithRootCAs(pool), WithSPKIPins(otherPin, serverPin)}, false},
+		{"verified chain mismatch", []HttpClientOption{WithRootCAs(pool), WithSPKIPins(otherPin)}, true},
+		{"pin without prefix", []HttpClientOption{WithRootCAs(pool), WithSPKIPins(strings.TrimPrefix(serverPin, "sha256/"))}, false},
+		{"unverified chain match", []HttpClientOption{WithVerifyCa(false), WithSPKIPins(serverPin)}, false},
+		{"unverified chain mismatch", []HttpClientOption{WithVerifyCa(false), WithSPKIPins(otherPin)}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			httpClientObj, err := NewHttpClient(zapLogger, testCase.options...)
+			if err != nil {
+				t.Fatalf("Test case Failed: %v", err)
+			}
+
+			response, err := httpClientObj.HttpClient.Get(server.URL)
+			if !testCase.mismatch {
+				if err != nil {
+					t.Fatalf("Test case Failed: %v", err)
+				}
+				response.Body.Close()
+				return
+			}
+
+			var pinMismatchError *PinMismatchError
+			if !errors.As(err, &pinMismatchError) {
+				t.Fatalf("expected a PinMismatchError, got %v", err)
+			}
+			if len(pinMismatchError.Presented) == 0 || pinMismatchError.Presented[0] != serverPin {
+				t.Errorf("Test case Failed, presented pins %v", pinMismatchError.Presented)
+			}
+			if !strings.Contains(err.Error(), "certificate pin mismatch for server") {
+				t.Errorf("Test case Failed, unexpected message %v", err)
+			}
+		})
+	}
+}
+
+func TestSPKIPinsInvalid(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+
+	for _, pins := range [][]string{{}, {"sha256/not-base64"}, {"sha256/" + base64.StdEncoding.EncodeToString([]byte("short"))}} {
+		if _, err := NewHttpClient(zapLogger, WithSPKIPins(pins...)); err == nil {
+			t.Errorf("Test case Failed, invalid pins %v must fail", pins)
+		}
+	}
+}
+
+func TestVerifyConnection(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+	server, pool := newPinningTestServer(t)
+
+	rejected := errors.New("rejected by callback")
+	httpClientObj, _ := NewHttpClient(zapLogger, WithRootCAs(pool), WithVerifyConnection(func(state tls.ConnectionState) error {
+		return rejected
+	}))
+
+	if _, err := httpClientObj.HttpClient.Get(server.URL); !errors.Is(err, rejected) {
+		t.Errorf("Test case Failed, expected the callback error, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+)
+
+// CertificateReloader serves the client certificate of mutual TLS connections and
+// reloads it when its files change on disk, so rotated certificates are used
+// without rebuilding the client. Connections opened before the change keep the
+// certificate they were opened with. CertificateReloader is goroutine-safe.
+type CertificateReloader struct {
+	mu          sync.RWMutex
+	paths       []string
+	load        func() (string, string, error)
+	fileStates  []fileState
+	certificate *tls.Certificate
+	log         logging.Logger
+}
+
+// fileState identifies a version of a file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewPEMCertificateReloader returns a reloader for a PEM certificate file and a PEM private key file.
+func NewPEMCertificateReloader(certificateFile string, certificateKeyFile string, logger logging.Logger) (*CertificateReloader, error) {
+	return NewEncryptedPEMCertificateReloader(certificateFile, certificateKeyFile, "", logger)
+}
+
+// NewEncryptedPEMCertificateReloader returns a reloader for a PEM certificate file and a PEM
+// private key file, an encrypted key is decrypted with certificateKeyPassword.
+func NewEncryptedPEMCertificateReloader(certificateFile string, certificateKeyFile string, certificateKeyPassword string, logger logging.Logger) (*CertificateReloader, error) {
+	load := func() (string, string, error) {
+		certificate, err := os.ReadFile(certificateFile)
+		if err != nil {
+			return "", "", err
+		}
+		certificateKey, err := os.ReadFile(certificateKeyFile)
+		if err != nil {
+			return "", "", err
+		}
+		decryptedKey, err := DecryptPrivateKeyPEM(string(certificateKey), certificateKeyPassword)
+		if err != nil {
+			return "", "", err
+		}
+		return string(certificate), decryptedKey, nil
+	}
+	return newCertificateReloader([]string{certificateFile, certificateKeyFile}, load, logger)
+}
+
+// NewPFXCertificateReloader returns a reloader for a pfx file, see GetPFXContent.
+func NewPFXCertificateReloader(clientCertificatePath string, clientCertificateName string, clientCertificatePassword string, logger logging.Logger) (*CertificateReloader, error) {
+	load := func() (string, string, error) {
+		return GetPFXContent(clientCertificatePath, clientCertificateName, clientCertificatePassword, logger)
+	}
+	return newCertificateReloader([]string{filepath.Join(clientCertificatePath, clientCertificateName)}, load, logger)
+}
+
+func newCertificateReloader(paths []string, load func() (string, string, error), logger logging.Logger) (*CertificateReloader, error) {
+	reloader := &CertificateReloader{
+		paths: paths,
+		load:  load,
+		log:   logger,
+	}
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// Reload loads and validates the certificate. On error the previous certificate is kept.
+func (reloader *CertificateReloader) Reload() error {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+	return reloader.reloadLocked()
+}
+
+func (reloader *CertificateReloader) reloadLocked() error {
+	// The state is read before the content so a change during the load is seen by the next check.
+	fileStates, err := reloader.currentFileStates()
+	if err != nil {
+		return err
+	}
+
+	certificate, certificateKey, err := reloader.load()
+	if err != nil {
+		return err
+	}
+
+	err = ValidateCertificateInfo(ValidationParams{
+		Certificate:    strings.TrimSpace(certificate),
+		CertificateKey: strings.TrimSpace(certificateKey),
+		Logger:         reloader.log,
+	})
+	if err != nil {
+		return err
+	}
+
+	keyPair, err := tls.X509KeyPair([]byte(certificate), []byte(certificateKey))
+	if err != nil {
+		return fmt.Errorf("issue parsing certificate public/private key pair of PEM encoded data: %w", err)
+	}
+
+	reloader.certificate = &keyPair
+	reloader.fileStates = fileStates
+	logging.Structured(reloader.log).DebugFields("loaded client certificate", logging.String("path", strings.Join(reloader.paths, ", ")))
+	return nil
+}
+
+// currentFileStates returns the state of the watched files.
+func (reloader *CertificateReloader) currentFileStates() ([]fileState, error) {
+	fileStates := make([]fileState, 0, len(reloader.paths))
+	for _, path := range reloader.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		fileStates = append(fileStates, fileState{modTime: info.ModTime(), size: info.Size()})
+	}
+	return fileStates, nil
+}
+
+// changed reports whether a watched file changed since the last successful load.
+func (reloader *CertificateReloader) changed() bool {
+	fileStates, err := reloader.currentFileStates()
+	if err != nil {
+		// Missing files, e.g. during a rotation, are reported by the next reload.
+		return true
+	}
+
+	reloader.mu.RLock()
+	defer reloader.mu.RUnlock()
+	for i, state := range fileStates {
+		if !state.modTime.Equal(reloader.fileStates[i].modTime) || state.size != reloader.fileStates[i].size {
+			return true
+		}
+	}
+	return false
+}
+
+// reloadIfChanged reloads the certificate when its files changed, errors are logged
+// and the previous certificate is kept.
+func (reloader *CertificateReloader) reloadIfChanged() {
+	if !reloader.changed() {
+		return
+	}
+
+	if err := reloader.Reload(); err != nil {
+		logging.Structured(reloader.log).ErrorFields("reloading client certificate failed, keeping the previous one", err, logging.String("path", strings.Join(reloader.paths, ", ")))
+	}
+}
+
+// GetClientCertificate is the tls.Config callback, it reloads the certificate first when its files changed.
+func (reloader *CertificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	reloader.reloadIfChanged()
+
+	reloader.mu.RLock()
+	defer reloader.mu.RUnlock()
+	if reloader.certificate == nil {
+		return nil, errors.New("no client certificate loaded")
+	}
+	return reloader.certificate, nil
+}
+
+// Watch checks the files every interval until ctx is done, so a broken rotation
+// is logged before the next connection needs the certificate.
+func (reloader *CertificateReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloader.reloadIfChanged()
+		}
+	}
+}
+
+// WithCertificateReloader serves the client certificate from reloader instead of a fixed certificate.
+func WithCertificateReloader(reloader *CertificateReloader) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if reloader == nil {
+			return errors.New("certificate reloader must not be nil")
+		}
+		settings.certificates = nil
+		settings.getClientCertificate = reloader.GetClientCertificate
+		return nil
+	}
+}
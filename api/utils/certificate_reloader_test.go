@@ -0,0 +1,146 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+// Unit tests for utils package.
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+)
+
+// newTestClientCertificate returns a self-signed PEM certificate and PKCS #8 key for commonName.
+func newTestClientCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	certificateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+	return string(certificate), string(certificateKey)
+}
+
+// writeCertificateFiles writes the pair and moves the modification time forward so the change is seen.
+func writeCertificateFiles(t *testing.T, certificateFile string, certificateKeyFile string, certificate string, certificateKey string, modTime time.Time) {
+	for path, content := range map[string]string{certificateFile: certificate, certificateKeyFile: certificateKey} {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCertificateReloader(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	directory := t.TempDir()
+	certificateFile := filepath.Join(directory, "tls.crt")
+	certificateKeyFile := filepath.Join(directory, "tls.key")
+	certificate, certificateKey := newTestClientCertificate(t, "first")
+	writeCertificateFiles(t, certificateFile, certificateKeyFile, certificate, certificateKey, time.Now().Add(-time.Minute))
+
+	reloader, err := NewPEMCertificateReloader(certificateFile, certificateKeyFile, zapLogger)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	httpClientObj, err := NewHttpClient(zapLogger, WithRootCAs(pool), WithCertificateReloader(reloader))
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	commonName := func() string {
+		httpClientObj.HttpClient.CloseIdleConnections()
+		response, err := httpClientObj.HttpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Test case Failed: %v", err)
+		}
+		defer response.Body.Close()
+		content, _ := io.ReadAll(response.Body)
+		return string(content)
+	}
+
+	if name := commonName(); name != "first" {
+		t.Errorf("Test case Failed, got certificate %v", name)
+	}
+
+	certificate, certificateKey = newTestClientCertificate(t, "second")
+	writeCertificateFiles(t, certificateFile, certificateKeyFile, certificate, certificateKey, time.Now())
+	if name := commonName(); name != "second" {
+		t.Errorf("Test case Failed, the rotated certificate was not loaded, got %v", name)
+	}
+
+	// A broken rotation keeps the previous certificate.
+	writeCertificateFiles(t, certificateFile, certificateKeyFile, "invalid", "invalid", time.Now().Add(time.Minute))
+	if name := commonName(); name != "second" {
+		t.Errorf("Test case Failed, got certificate %v", name)
+	}
+	if err = reloader.Reload(); err == nil {
+		t.Error("reloading an invalid certificate must fail")
+	}
+}
+
+func TestCertificateReloaderInvalid(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+	directory := t.TempDir()
+	certificateFile := filepath.Join(directory, "tls.crt")
+	certificateKeyFile := filepath.Join(directory, "tls.key")
+
+	if _, err := NewPEMCertificateReloader(certificateFile, certificateKeyFile, zapLogger); err == nil {
+		t.Error("missing files must fail")
+	}
+
+	certificate, _ := newTestClientCertificate(t, "first")
+	_, otherKey := newTestClientCertificate(t, "other")
+	writeCertificateFiles(t, certificateFile, certificateKeyFile, certificate, otherKey, time.Now())
+	if _, err := NewPEMCertificateReloader(certificateFile, certificateKeyFile, zapLogger); err == nil {
+		t.Error("a key that does not match the certificate must fail")
+	}
+
+	if _, err := NewPFXCertificateReloader(directory, "missing.pfx", "", zapLogger); err == nil {
+		t.Error("a missing pfx file must fail")
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+)
+
+// CircuitState is the state of the circuit breaker, see WithCircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through.
+	CircuitClosed CircuitState = iota
+	// CircuitHalfOpen lets a single probe call through, the others fail fast.
+	CircuitHalfOpen
+	// CircuitOpen fails every call fast.
+	CircuitOpen
+)
+
+func (state CircuitState) String() string {
+	switch state {
+	case CircuitClosed:
+		return "closed"
+	case CircuitHalfOpen:
+		return "half-open"
+	case CircuitOpen:
+		return "open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(state))
+}
+
+// CircuitObserver is implemented by request observers that also track the state
+// of the circuit breaker, see HttpClientObj.SetRequestObserver.
+type CircuitObserver interface {
+	// ObserveCircuitState is called every time the circuit breaker changes state.
+	ObserveCircuitState(state CircuitState)
+}
+
+// CircuitOpenError is returned without calling Password Safe while the circuit
+// breaker is open. It is never retried.
+type CircuitOpenError struct {
+	// RetryAfter is the time left until a probe call is let through.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open after consecutive Password Safe failures, next attempt in %v", e.RetryAfter)
+}
+
+// circuitOutcome is the result of a call let through by the circuit breaker.
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	// circuitIgnored calls say nothing about the server, e.g. canceled calls.
+	circuitIgnored
+)
+
+// circuitBreaker opens after failureThreshold consecutive failures, fails calls fast
+// for openTimeout and then lets one probe call through: the circuit closes when it
+// succeeds and opens again when it fails.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openTimeout      time.Duration
+	state            CircuitState
+	failures         int
+	openedAt         time.Time
+	probing          bool
+	now              func() time.Time
+}
+
+// allow returns a CircuitOpenError when the call must fail fast, and the state before and after the call.
+func (breaker *circuitBreaker) allow() (CircuitState, CircuitState, error) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	from := breaker.state
+	if breaker.state == CircuitOpen {
+		if elapsed := breaker.now().Sub(breaker.openedAt); elapsed < breaker.openTimeout {
+			return from, breaker.state, &CircuitOpenError{RetryAfter: breaker.openTimeout - elapsed}
+		}
+		breaker.state = CircuitHalfOpen
+	}
+	if breaker.state == CircuitHalfOpen {
+		if breaker.probing {
+			return from, breaker.state, &CircuitOpenError{RetryAfter: 0}
+		}
+		breaker.probing = true
+	}
+	return from, breaker.state, nil
+}
+
+// record records the outcome of a call let through by allow and returns the state before and after it.
+func (breaker *circuitBreaker) record(outcome circuitOutcome) (CircuitState, CircuitState) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	from := breaker.state
+	probe := breaker.state == CircuitHalfOpen
+	if probe {
+		breaker.probing = false
+	}
+
+	switch outcome {
+	case circuitSuccess:
+		breaker.failures = 0
+		breaker.state = CircuitClosed
+	case circuitFailure:
+		breaker.failures++
+		if probe || breaker.failures >= breaker.failureThreshold {
+			breaker.state = CircuitOpen
+			breaker.openedAt = breaker.now()
+		}
+	}
+	return from, breaker.state
+}
+
+// currentState returns the state, an open circuit whose timeout elapsed is reported half-open.
+func (breaker *circuitBreaker) currentState() CircuitState {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	if breaker.state == CircuitOpen && breaker.now().Sub(breaker.openedAt) >= breaker.openTimeout {
+		return CircuitHalfOpen
+	}
+	return breaker.state
+}
+
+// WithCircuitBreaker opens the circuit after failureThreshold consecutive technical
+// failures (connection errors, 5xx and 408 responses): calls then fail fast with a
+// CircuitOpenError for openTimeout, after which a single probe call decides whether
+// the circuit closes again. The circuit is shared by the copies of the client.
+func WithCircuitBreaker(failureThreshold int, openTimeout time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if failureThreshold < 1 {
+			return errors.New("circuit breaker failure threshold must be at least 1")
+		}
+		if openTimeout <= 0 {
+			return errors.New("circuit breaker open timeout must be greater than 0")
+		}
+		settings.circuitBreaker = &circuitBreaker{
+			failureThreshold: failureThreshold,
+			openTimeout:      openTimeout,
+			now:              time.Now,
+		}
+		return nil
+	}
+}
+
+// CircuitState returns the state of the circuit breaker, always CircuitClosed without one.
+func (client *HttpClientObj) CircuitState() CircuitState {
+	if client.circuitBreaker == nil {
+		return CircuitClosed
+	}
+	return client.circuitBreaker.currentState()
+}
+
+// allowCall asks the circuit breaker, when there is one, whether a call may be sent.
+func (client *HttpClientObj) allowCall() error {
+	if client.circuitBreaker == nil {
+		return nil
+	}
+	from, to, err := client.circuitBreaker.allow()
+	client.notifyCircuitState(from, to)
+	return err
+}
+
+// recordCall records the technical error of a call let through by allowCall.
+func (client *HttpClientObj) recordCall(technicalError error) {
+	if client.circuitBreaker == nil {
+		return
+	}
+
+	outcome := circuitSuccess
+	var rateLimitedError *RateLimitedError
+	switch {
+	case technicalError == nil, errors.As(technicalError, &rateLimitedError):
+		// Business errors and rate limits are answered by a working server.
+	case errors.Is(technicalError, context.Canceled):
+		outcome = circuitIgnored
+	default:
+		outcome = circuitFailure
+	}
+
+	from, to := client.circuitBreaker.record(outcome)
+	client.notifyCircuitState(from, to)
+}
+
+// notifyCircuitState logs a state change and reports it to the observer.
+func (client *HttpClientObj) notifyCircuitState(from CircuitState, to CircuitState) {
+	if from == to {
+		return
+	}
+	fields := []logging.Field{logging.String("from", from.String()), logging.String("to", to.String())}
+	if to == CircuitOpen {
+		logging.Structured(client.log).WarnFields("circuit breaker state changed", fields...)
+	} else {
+		logging.Structured(client.log).InfoFields("circuit breaker state changed", fields...)
+	}
+	if circuitObserver, ok := client.observer.(CircuitObserver); ok {
+		circuitObserver.ObserveCircuitState(to)
+	}
+}
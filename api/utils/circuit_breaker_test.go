@@ -0,0 +1,180 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+// Unit tests for utils package.
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+)
+
+// circuitRecorder records the states reported to a CircuitObserver.
+type circuitRecorder struct {
+	states []CircuitState
+}
+
+func (recorder *circuitRecorder) ObserveRequest(string, int, time.Duration) {}
+
+func (recorder *circuitRecorder) ObserveRetry(string) {}
+
+func (recorder *circuitRecorder) ObserveCircuitState(state CircuitState) {
+	recorder.states = append(recorder.states, state)
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	breaker := &circuitBreaker{failureThreshold: 2, openTimeout: time.Minute, now: func() time.Time { return now }}
+
+	for range 2 {
+		if _, _, err := breaker.allow(); err != nil {
+			t.Fatalf("Test case Failed: %v", err)
+		}
+		breaker.record(circuitFailure)
+	}
+	if breaker.currentState() != CircuitOpen {
+		t.Fatalf("Test case Failed, state %v", breaker.currentState())
+	}
+
+	now = now.Add(20 * time.Second)
+	var circuitOpenError *CircuitOpenError
+	if _, _, err := breaker.allow(); !errors.As(err, &circuitOpenError) || circuitOpenError.RetryAfter != 40*time.Second {
+		t.Fatalf("Test case Failed, expected a CircuitOpenError: %v", err)
+	}
+
+	// A failed probe opens the circuit again.
+	now = now.Add(40 * time.Second)
+	if _, to, err := breaker.allow(); err != nil || to != CircuitHalfOpen {
+		t.Fatalf("Test case Failed, expected a probe: %v %v", to, err)
+	}
+	if _, _, err := breaker.allow(); err == nil {
+		t.Error("Test case Failed, a single probe is let through")
+	}
+	if _, to := breaker.record(circuitFailure); to != CircuitOpen {
+		t.Fatalf("Test case Failed, state %v", to)
+	}
+
+	// A successful probe closes it.
+	now = now.Add(time.Minute)
+	if _, _, err := breaker.allow(); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if _, to := breaker.record(circuitSuccess); to != CircuitClosed {
+		t.Fatalf("Test case Failed, state %v", to)
+	}
+
+	// Successes reset the consecutive failures.
+	breaker.record(circuitFailure)
+	breaker.record(circuitSuccess)
+	breaker.record(circuitFailure)
+	if breaker.currentState() != CircuitClosed {
+		t.Errorf("Test case Failed, state %v", breaker.currentState())
+	}
+}
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`"secret"`))
+	}))
+	defer server.Close()
+
+	httpClientObj, err := NewHttpClient(logging.NewZapLogger(zap.NewNop()), WithCircuitBreaker(3, 200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	recorder := &circuitRecorder{}
+	httpClientObj.SetRequestObserver(recorder)
+
+	callSecretSafeAPIObj := &entities.CallSecretSafeAPIObj{
+		Url:         server.URL,
+		HttpMethod:  "GET",
+		Body:        bytes.Buffer{},
+		Method:      constants.SecretGetSecretByPath,
+		ContentType: "application/json",
+	}
+
+	// The retries stop once the circuit opens instead of running for the whole backoff.
+	start := time.Now()
+	_, err = httpClientObj.MakeRequest(callSecretSafeAPIObj, rateLimitBackoff(time.Minute))
+	var circuitOpenError *CircuitOpenError
+	if !errors.As(err, &circuitOpenError) {
+		t.Fatalf("Test case Failed, expected a CircuitOpenError: %v", err)
+	}
+	if calls.Load() != 3 || time.Since(start) > 10*time.Second || httpClientObj.CircuitState() != CircuitOpen {
+		t.Errorf("Test case Failed, %v calls in %v, state %v", calls.Load(), time.Since(start), httpClientObj.CircuitState())
+	}
+
+	// Copies of the client share the circuit.
+	httpClientCopy := *httpClientObj
+	if _, err = httpClientCopy.MakeRequest(callSecretSafeAPIObj, rateLimitBackoff(time.Minute)); !errors.As(err, &circuitOpenError) || calls.Load() != 3 {
+		t.Errorf("Test case Failed, expected a fast failure: %v, %v calls", err, calls.Load())
+	}
+
+	healthy.Store(true)
+	time.Sleep(250 * time.Millisecond)
+	if httpClientObj.CircuitState() != CircuitHalfOpen {
+		t.Errorf("Test case Failed, state %v", httpClientObj.CircuitState())
+	}
+	if _, err = httpClientObj.MakeRequest(callSecretSafeAPIObj, rateLimitBackoff(time.Minute)); err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	expected := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(recorder.states) != len(expected) || httpClientObj.CircuitState() != CircuitClosed {
+		t.Fatalf("Test case Failed, states %v, expected %v", recorder.states, expected)
+	}
+	for i, state := range expected {
+		if recorder.states[i] != state {
+			t.Errorf("Test case Failed, states %v, expected %v", recorder.states, expected)
+		}
+	}
+}
+
+func TestCircuitBreakerIgnoresBusinessErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	httpClientObj, _ := NewHttpClient(logging.NewZapLogger(zap.NewNop()), WithCircuitBreaker(1, time.Minute))
+	for range 3 {
+		if _, _, technicalError, businessError := httpClientObj.HttpRequest(server.URL, "GET", bytes.Buffer{}, "", "", "application/json", ""); technicalError != nil || businessError == nil {
+			t.Fatalf("Test case Failed: %v %v", technicalError, businessError)
+		}
+	}
+	if httpClientObj.CircuitState() != CircuitClosed {
+		t.Errorf("Test case Failed, state %v", httpClientObj.CircuitState())
+	}
+}
+
+func TestCircuitBreakerOptions(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+
+	if _, err := NewHttpClient(zapLogger, WithCircuitBreaker(0, time.Minute)); err == nil {
+		t.Error("Test case Failed, a zero threshold must fail")
+	}
+	if _, err := NewHttpClient(zapLogger, WithCircuitBreaker(1, 0)); err == nil {
+		t.Error("Test case Failed, a zero open timeout must fail")
+	}
+
+	httpClientObj, _ := NewHttpClient(zapLogger)
+	if httpClientObj.CircuitState() != CircuitClosed || CircuitHalfOpen.String() != "half-open" {
+		t.Error("Test case Failed, a client without circuit breaker is closed")
+	}
+}
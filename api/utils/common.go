@@ -4,6 +4,7 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -67,10 +68,10 @@ func DeleteResourceByID(
 	var technicalError error
 	var businessError error
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = httpClient.Retry(callSecretSafeAPIObj, exponentialBackOff, func() error {
 		_, _, technicalError, businessError = httpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, exponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return technicalError
@@ -81,6 +82,67 @@ func DeleteResourceByID(
 	return nil
 }
 
+// GetResource is a reusable function for calling a GET endpoint and unmarshaling
+// its json response into result.
+func GetResource(
+	url string,
+	apiVersion string,
+	methodConstant string,
+	result interface{},
+	httpClient *HttpClientObj,
+	exponentialBackOff *backoff.ExponentialBackOff,
+	logger logging.Logger,
+) error {
+	messageLog := fmt.Sprintf("%v %v", "GET", url)
+	logger.Debug(messageLog)
+
+	response, err := httpClient.GetGeneralList(url, apiVersion, methodConstant, exponentialBackOff)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(response, result)
+}
+
+// SendResource is a reusable function for calling an endpoint with payload as
+// json body, when set, and returning the response body.
+func SendResource(
+	url string,
+	httpMethod string,
+	apiVersion string,
+	methodConstant string,
+	payload interface{},
+	httpClient *HttpClientObj,
+	exponentialBackOff *backoff.ExponentialBackOff,
+	logger logging.Logger,
+) ([]byte, error) {
+	body := bytes.Buffer{}
+	if payload != nil {
+		// Convert payload to json string.
+		objBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = *bytes.NewBuffer(objBytes)
+	}
+
+	messageLog := fmt.Sprintf("%v %v", httpMethod, url)
+	logger.Debug(messageLog)
+
+	callSecretSafeAPIObj := &entities.CallSecretSafeAPIObj{
+		Url:         url,
+		HttpMethod:  httpMethod,
+		Body:        body,
+		Method:      methodConstant,
+		AccessToken: "",
+		ApiKey:      "",
+		ContentType: "application/json",
+		ApiVersion:  apiVersion,
+	}
+
+	return httpClient.MakeRequest(callSecretSafeAPIObj, exponentialBackOff)
+}
+
 
 // GetOwnerDetailsOwnerIdList get Owners details list.
 func GetOwnerDetailsOwnerIdList(data map[string]interface{}, signAppinResponse entities.SignAppinResponse) []entities.OwnerDetailsOwnerId {
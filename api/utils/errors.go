@@ -0,0 +1,91 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils error types returned by the library calls.
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// StatusCodeError is returned when Password Safe answers a request with an error status code.
+type StatusCodeError struct {
+	StatusCode int
+	Status     string
+	HttpMethod string
+	Body       string
+	// RetryAfter is the wait requested by the Retry-After header of a 503 response.
+	RetryAfter time.Duration
+}
+
+// Error keeps the messages the library has always returned, callers match on them.
+func (e *StatusCodeError) Error() string {
+	if e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusRequestTimeout {
+		return fmt.Sprintf("error %s: StatusCode: %d, Status: %s", e.HttpMethod, e.StatusCode, e.Status)
+	}
+	return fmt.Sprintf("error - status code: %v - %v", e.StatusCode, e.Body)
+}
+
+// StatusCode returns the status code of the first StatusCodeError in err's chain, 0 when there is none.
+func StatusCode(err error) int {
+	var statusCodeError *StatusCodeError
+	if errors.As(err, &statusCodeError) {
+		return statusCodeError.StatusCode
+	}
+	return 0
+}
+
+// NotFoundError is returned when the requested secret, folder, account or object does not exist
+// in Password Safe, Message is the error message.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// NewNotFoundError returns a NotFoundError whose message is formatted from format and args.
+func NewNotFoundError(format string, args ...interface{}) error {
+	return &NotFoundError{Message: fmt.Sprintf(format, args...)}
+}
+
+// IsTechnicalError reports whether err means Password Safe could not answer the
+// request: connection errors, 5xx and 408 responses, rate limiting and an open
+// circuit. Rejections such as 4xx responses or missing secrets are not technical.
+func IsTechnicalError(err error) bool {
+	var rateLimitedError *RateLimitedError
+	var circuitOpenError *CircuitOpenError
+	if errors.As(err, &rateLimitedError) || errors.As(err, &circuitOpenError) {
+		return true
+	}
+	if statusCode := StatusCode(err); statusCode != 0 {
+		return statusCode >= http.StatusInternalServerError || statusCode == http.StatusRequestTimeout
+	}
+	var urlError *url.Error
+	var netError net.Error
+	return errors.As(err, &urlError) || errors.As(err, &netError)
+}
+
+// RateLimitedError is returned when a request is over the rate limit, either the
+// budget of the client (see WithRateLimit), Err is nil then, or the server's, Err
+// is then the 429 StatusCodeError. Retrying calls retry it after RetryAfter until
+// the retry time runs out.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("rate limited: client request budget exhausted, retry after %v", e.RetryAfter)
+	}
+	return fmt.Sprintf("rate limited by server, retry after %v: %v", e.RetryAfter, e.Err)
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
@@ -5,17 +5,15 @@ package utils
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
+	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"net/http/cookiejar"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
 
@@ -25,59 +23,65 @@ import (
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
 	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
 	"github.com/cenkalti/backoff/v4"
-	"golang.org/x/crypto/pkcs12"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
 )
 
+// RequestObserver receives instrumentation events for every call made through
+// HttpClientObj, e.g. to feed a metrics collector.
+type RequestObserver interface {
+	// ObserveRequest is called once per API call with the method name (see the
+	// constants package), the HTTP status code (0 when no response was received)
+	// and the time spent waiting for the response.
+	ObserveRequest(method string, statusCode int, elapsed time.Duration)
+	// ObserveRetry is called every time a failed call is about to be retried.
+	ObserveRetry(method string)
+}
+
 // HttpClientObj responsible for http request instance.
 type HttpClientObj struct {
 	HttpClient *http.Client
 	Context    context.Context
 	log        logging.Logger
+	observer   RequestObserver
+	// rateLimiter and circuitBreaker are shared by the copies of the client, nil disables them.
+	rateLimiter    *rateLimiter
+	circuitBreaker *circuitBreaker
+	maxRetryAfter  time.Duration
+	retryPolicy    RetryPolicy
 }
 
 // GetHttpClient is responsible for configuring an HTTP client and transport for API calls.
+// It is kept for compatibility, NewHttpClient accepts more options.
 func GetHttpClient(clientTimeOut int, verifyCa bool, certificate string, certificate_key string, logger logging.Logger) (*HttpClientObj, error) {
-	var cert tls.Certificate
+	return NewHttpClient(logger,
+		WithTimeout(time.Second*time.Duration(clientTimeOut)),
+		WithVerifyCa(verifyCa),
+		WithClientCertificate(certificate, certificate_key),
+	)
+}
 
-	if certificate != "" && certificate_key != "" {
-		certi, err := tls.X509KeyPair([]byte(certificate), []byte(certificate_key))
+// SetRequestObserver registers an observer notified about every API call and retry.
+// HttpClientObj is copied by value into AuthenticationObj, so the observer must be
+// set before the client is passed to authentication.Authenticate.
+func (client *HttpClientObj) SetRequestObserver(observer RequestObserver) {
+	client.observer = observer
+}
 
-		if err != nil {
-			logger.Error("issue parsing certificate public/private key pair of PEM encoded data.")
-			return nil, err
+// NotifyRetry returns a backoff.Notify function that logs the retry and reports it
+// to the request observer, if any.
+func (client *HttpClientObj) NotifyRetry(method string) backoff.Notify {
+	return func(err error, wait time.Duration) {
+		logging.Structured(client.log).DebugFields("retrying request", logging.String("method", method), logging.Duration("wait", wait), logging.String("error", err.Error()))
+		if client.observer != nil {
+			client.observer.ObserveRetry(method)
 		}
-
-		cert = certi
-	}
-
-	// TSL Config
-	var tr = &http.Transport{
-		TLSClientConfig: &tls.Config{
-			Renegotiation:      tls.RenegotiateOnceAsClient,
-			InsecureSkipVerify: !verifyCa,
-			Certificates:       []tls.Certificate{cert},
-			MinVersion:         tls.VersionTLS12,
-		},
+		// backoff waits after notifying, so only the rest of a longer Retry-After is waited here.
+		client.waitRetryAfter(err, wait)
 	}
-
-	var jar, _ = cookiejar.New(nil)
-
-	// Client
-	var client = &http.Client{
-		Transport: tr,
-		Jar:       jar,
-		Timeout:   time.Second * time.Duration(clientTimeOut),
-	}
-
-	httpClientObj := &HttpClientObj{
-		HttpClient: client,
-		log:        logger,
-	}
-
-	return httpClientObj, nil
 }
 
-// GetPFXContent decrypt pfx certificate.
+// GetPFXContent decrypt pfx certificate. It returns the certificate followed by its CA chain
+// and the key as PKCS #8, modern AES encrypted pfx files are supported.
 func GetPFXContent(clientCertificatePath string, clientCertificateName string, clientCertificatePassword string, logger logging.Logger) (string, string, error) {
 
 	if clientCertificateName == "" {
@@ -90,37 +94,26 @@ func GetPFXContent(clientCertificatePath string, clientCertificateName string, c
 		return "", "", err
 	}
 
-	pfxFileBlock, err := pkcs12.ToPEM(pfxFile, clientCertificatePassword)
+	privateKey, certificate, caCertificates, err := pkcs12.DecodeChain(pfxFile, clientCertificatePassword)
 	if err != nil {
 		logger.Error(err.Error())
 		return "", "", err
 	}
 
-	var keyBlock, certificateBlock *pem.Block
-	for _, pemBlock := range pfxFileBlock {
-		switch pemBlock.Type {
-		case "PRIVATE KEY":
-			keyBlock = pemBlock
-		case "CERTIFICATE":
-			certificateBlock = pemBlock
-		}
-	}
-
-	if keyBlock == nil {
-		err = errors.New("error getting Key Block")
-		logger.Error(err.Error())
-		return "", "", err
-	}
-	if certificateBlock == nil {
-		err = errors.New("error getting Certificate Block")
+	privateKeyDer, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		err = fmt.Errorf("error getting Key Block: %w", err)
 		logger.Error(err.Error())
 		return "", "", err
 	}
 
-	privateKeyData := pem.EncodeToMemory(keyBlock)
-	certData := pem.EncodeToMemory(certificateBlock)
+	var certData bytes.Buffer
+	for _, chainCertificate := range append([]*x509.Certificate{certificate}, caCertificates...) {
+		_ = pem.Encode(&certData, &pem.Block{Type: "CERTIFICATE", Bytes: chainCertificate.Raw})
+	}
+	privateKeyData := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDer})
 
-	return string(certData), string(privateKeyData), nil
+	return certData.String(), string(privateKeyData), nil
 }
 
 // CallSecretSafeAPI prepares http call
@@ -128,6 +121,7 @@ func GetPFXContent(clientCertificatePath string, clientCertificateName string, c
 
 func (client *HttpClientObj) CallSecretSafeAPI(callSecretSafeAPIObj entities.CallSecretSafeAPIObj) (io.ReadCloser, int, error, error) {
 
+	start := time.Now()
 	response, scode, technicalError, businessError := client.HttpRequest(callSecretSafeAPIObj.Url,
 		callSecretSafeAPIObj.HttpMethod,
 		callSecretSafeAPIObj.Body,
@@ -137,14 +131,16 @@ func (client *HttpClientObj) CallSecretSafeAPI(callSecretSafeAPIObj entities.Cal
 		callSecretSafeAPIObj.ApiVersion,
 	)
 
+	if client.observer != nil {
+		client.observer.ObserveRequest(callSecretSafeAPIObj.Method, scode, time.Since(start))
+	}
+
 	if technicalError != nil {
-		messageLog := fmt.Sprintf("Error in %s %s \n", callSecretSafeAPIObj.Method, technicalError.Error())
-		client.log.Error(messageLog)
+		logging.Structured(client.log).ErrorFields("request failed", technicalError, logging.String("method", callSecretSafeAPIObj.Method), logging.Int("statusCode", scode))
 	}
 
 	if businessError != nil {
-		messageLog := fmt.Sprintf("Error in %s: %s \n", callSecretSafeAPIObj.Method, businessError.Error())
-		client.log.Debug(messageLog)
+		logging.Structured(client.log).DebugFields("request rejected", logging.String("method", callSecretSafeAPIObj.Method), logging.Int("statusCode", scode), logging.String("error", businessError.Error()))
 	}
 	return response, scode, technicalError, businessError
 }
@@ -192,7 +188,7 @@ func resolveContext(ctx context.Context) context.Context {
 
 // handleDoError builds the appropriate return values when http.Client.Do returns an error.
 func (client *HttpClientObj) handleDoError(resp *http.Response, err error) (io.ReadCloser, int, error, error) {
-	client.log.Debug(fmt.Sprintf("%v %v", "Error Making request: ", err.Error()))
+	logging.Structured(client.log).DebugFields("error making request", logging.String("error", err.Error()))
 	if resp != nil {
 		return nil, resp.StatusCode, err, nil
 	}
@@ -210,7 +206,10 @@ func (client *HttpClientObj) handleResponseStatus(resp *http.Response, method st
 		// Do not include the outbound request body in error logs: it may contain
 		// authentication credentials or secret material (and, due to concurrent
 		// write/read in the HTTP transport, may still hold unsent secret bytes).
-		err := fmt.Errorf("error %s: StatusCode: %d, Status: %s", method, resp.StatusCode, resp.Status)
+		err := &StatusCodeError{StatusCode: resp.StatusCode, Status: resp.Status, HttpMethod: method}
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			err.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
 		client.log.Error(err.Error())
 		return nil, resp.StatusCode, err, nil
 	}
@@ -222,37 +221,37 @@ func (client *HttpClientObj) handleResponseStatus(resp *http.Response, method st
 			client.log.Error(err.Error())
 			return nil, resp.StatusCode, err, nil
 		}
-		return nil, resp.StatusCode, nil, fmt.Errorf("error - status code: %v - %v", resp.StatusCode, respBody)
+		statusCodeError := &StatusCodeError{StatusCode: resp.StatusCode, Status: resp.Status, HttpMethod: method, Body: respBody.String()}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// Rate limiting is temporary, return it as a technical error so it is retried.
+			return nil, resp.StatusCode, &RateLimitedError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), Err: statusCodeError}, nil
+		}
+		return nil, resp.StatusCode, nil, statusCodeError
 	}
 	return resp.Body, resp.StatusCode, nil, nil
 }
 
-// sensitiveURLPathRE matches the credential-bearing path segment in
-// /Credentials/<requestId> and /Requests/<requestId>(/checkin). The capture
-// preserves the original case of the resource name; [^/?#]+ matches a single
-// path segment regardless of how it is percent-encoded.
-var sensitiveURLPathRE = regexp.MustCompile(`(?i)(/(?:credentials|requests))/[^/?#]+`)
-
 // RedactSensitiveURL masks sensitive path components (such as credential
 // request IDs) so they never appear in clear text in log output. The request
 // ID is the short-lived token that, presented to GET /Credentials/{requestId},
 // returns a plaintext managed-account password, so it must be redacted at every
 // log emission point.
 //
-// The redaction runs directly against the raw URL string. The previous
-// implementation parsed the URL, redacted the segments of EscapedPath(), and
-// then string-replaced EscapedPath() back into the original URL. That step
-// silently failed when EscapedPath() emitted a normalized form (e.g., %2A
-// decoded to "*", %41 decoded to "A") that did not appear byte-for-byte in
-// the input, returning the URL with the request ID intact.
+// The redaction runs directly against the raw URL string (see
+// logging.RedactRequestIDs). The previous implementation parsed the URL,
+// redacted the segments of EscapedPath(), and then string-replaced
+// EscapedPath() back into the original URL. That step silently failed when
+// EscapedPath() emitted a normalized form (e.g., %2A decoded to "*", %41
+// decoded to "A") that did not appear byte-for-byte in the input, returning
+// the URL with the request ID intact.
 func RedactSensitiveURL(rawURL string) string {
-	return sensitiveURLPathRE.ReplaceAllString(rawURL, "$1/****")
+	return logging.RedactRequestIDs(rawURL)
 }
 
 // HttpRequest makes http request to the server.
 func (client *HttpClientObj) HttpRequest(url string, method string, body bytes.Buffer, accessToken string, apiKey string, contentType string, apiVersion string) (io.ReadCloser, int, error, error) {
 	url = client.SetApiVersion(url, apiVersion)
-	client.log.Debug(fmt.Sprintf("Entire URL: %s", RedactSensitiveURL(url)))
+	logging.Structured(client.log).DebugFields("sending request", logging.String("httpMethod", method), logging.String("url", RedactSensitiveURL(url)))
 
 	req, err := http.NewRequestWithContext(resolveContext(client.Context), method, url, &body)
 	if err != nil {
@@ -264,11 +263,24 @@ func (client *HttpClientObj) HttpRequest(url string, method string, body bytes.B
 		req.Header.Set("Authorization", authorizationHeader)
 	}
 
+	if client.rateLimiter != nil {
+		if wait := client.rateLimiter.take(); wait > 0 {
+			return nil, 0, &RateLimitedError{RetryAfter: wait}, nil
+		}
+	}
+
+	if err = client.allowCall(); err != nil {
+		return nil, 0, err, nil
+	}
+
 	resp, err := client.HttpClient.Do(req)
 	if err != nil {
+		client.recordCall(err)
 		return client.handleDoError(resp, err)
 	}
-	return client.handleResponseStatus(resp, method, body)
+	response, scode, technicalError, businessError := client.handleResponseStatus(resp, method, body)
+	client.recordCall(technicalError)
+	return response, scode, technicalError, businessError
 }
 
 // CreateMultipartRequest creates and sends multipart request.
@@ -331,10 +343,10 @@ func (client *HttpClientObj) MakeRequest(callSecretSafeAPIObj *entities.CallSecr
 	var technicalError error
 	var businessError error
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = client.Retry(callSecretSafeAPIObj, exponentialBackOff, func() error {
 		body, _, technicalError, businessError = client.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, exponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return nil, technicalError
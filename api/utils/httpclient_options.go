@@ -0,0 +1,302 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"os"
+	"strings"
+	"time"
+
+	urlnet "net/url"
+
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+)
+
+// HttpClientOption configures the client built by NewHttpClient.
+type HttpClientOption func(settings *httpClientSettings) error
+
+// httpClientSettings collects the values set by the options.
+type httpClientSettings struct {
+	timeout               time.Duration
+	verifyCa              bool
+	certificates          []tls.Certificate
+	rootCAs               *x509.CertPool
+	proxy                 func(*http.Request) (*urlnet.URL, error)
+	dialTimeout           time.Duration
+	tlsHandshakeTimeout   time.Duration
+	idleConnTimeout       time.Duration
+	responseHeaderTimeout time.Duration
+	maxConnsPerHost       int
+	maxIdleConnsPerHost   int
+	tlsMinVersion         uint16
+	http2                 bool
+	pins                  map[string]bool
+	getClientCertificate  func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+	verifyConnections     []func(state tls.ConnectionState) error
+	rateLimiter           *rateLimiter
+	maxRetryAfter         time.Duration
+	retryPolicy           RetryPolicy
+	circuitBreaker        *circuitBreaker
+}
+
+// NewHttpClient configures an HTTP client and transport for API calls. Without options
+// the client verifies the certificate authority against the system roots, requires
+// TLS 1.2, uses no proxy and has a 30 seconds timeout.
+func NewHttpClient(logger logging.Logger, options ...HttpClientOption) (*HttpClientObj, error) {
+	settings := httpClientSettings{
+		timeout:       30 * time.Second,
+		verifyCa:      true,
+		tlsMinVersion: tls.VersionTLS12,
+	}
+	for _, option := range options {
+		if err := option(&settings); err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   settings.dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	// TLS Config
+	var tr = &http.Transport{
+		Proxy:       settings.proxy,
+		DialContext: dialer.DialContext,
+		TLSClientConfig: &tls.Config{
+			Renegotiation:        tls.RenegotiateOnceAsClient,
+			InsecureSkipVerify:   !settings.verifyCa,
+			Certificates:         settings.certificates,
+			GetClientCertificate: settings.getClientCertificate,
+			RootCAs:              settings.rootCAs,
+			MinVersion:           settings.tlsMinVersion,
+			VerifyConnection:     settings.verifyConnection(),
+		},
+		TLSHandshakeTimeout:   settings.tlsHandshakeTimeout,
+		IdleConnTimeout:       settings.idleConnTimeout,
+		ResponseHeaderTimeout: settings.responseHeaderTimeout,
+		MaxConnsPerHost:       settings.maxConnsPerHost,
+		MaxIdleConnsPerHost:   settings.maxIdleConnsPerHost,
+		ForceAttemptHTTP2:     settings.http2,
+	}
+
+	var jar, _ = cookiejar.New(nil)
+
+	// Client
+	var client = &http.Client{
+		Transport: tr,
+		Jar:       jar,
+		Timeout:   settings.timeout,
+	}
+
+	httpClientObj := &HttpClientObj{
+		HttpClient:     client,
+		log:            logger,
+		rateLimiter:    settings.rateLimiter,
+		maxRetryAfter:  settings.maxRetryAfter,
+		retryPolicy:    settings.retryPolicy,
+		circuitBreaker: settings.circuitBreaker,
+	}
+
+	return httpClientObj, nil
+}
+
+// WithTimeout sets the time limit of a whole request, zero means no limit.
+func WithTimeout(timeout time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.timeout = timeout
+		return nil
+	}
+}
+
+// WithVerifyCa sets whether the server certificate authority is verified, false is insecure.
+func WithVerifyCa(verifyCa bool) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.verifyCa = verifyCa
+		return nil
+	}
+}
+
+// WithClientCertificate sets the PEM encoded client certificate and key, it is ignored when either is empty.
+func WithClientCertificate(certificate string, certificateKey string) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if certificate == "" || certificateKey == "" {
+			return nil
+		}
+
+		cert, err := tls.X509KeyPair([]byte(certificate), []byte(certificateKey))
+		if err != nil {
+			return fmt.Errorf("issue parsing certificate public/private key pair of PEM encoded data: %w", err)
+		}
+
+		settings.certificates = []tls.Certificate{cert}
+		settings.getClientCertificate = nil
+		return nil
+	}
+}
+
+// WithEncryptedClientCertificate sets the PEM encoded client certificate and the encrypted
+// PKCS #8 key, decrypted with certificateKeyPassword. Unencrypted keys are accepted too.
+func WithEncryptedClientCertificate(certificate string, certificateKey string, certificateKeyPassword string) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if certificate == "" || certificateKey == "" {
+			return nil
+		}
+
+		decryptedKey, err := DecryptPrivateKeyPEM(certificateKey, certificateKeyPassword)
+		if err != nil {
+			return err
+		}
+		return WithClientCertificate(certificate, decryptedKey)(settings)
+	}
+}
+
+// WithRootCAsPEM trusts the PEM encoded CA certificates instead of the system roots.
+// Several root CA options add to the same pool.
+func WithRootCAsPEM(rootCAs string) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if settings.rootCAs == nil {
+			settings.rootCAs = x509.NewCertPool()
+		}
+		if !settings.rootCAs.AppendCertsFromPEM([]byte(rootCAs)) {
+			return errors.New("no valid CA certificate found in root CAs PEM")
+		}
+		return nil
+	}
+}
+
+// WithRootCAsFile trusts the CA certificates of a PEM file instead of the system roots.
+func WithRootCAsFile(path string) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return WithRootCAsPEM(string(content))(settings)
+	}
+}
+
+// WithProxyURL sends requests through the proxy at proxyUrl.
+func WithProxyURL(proxyUrl string) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		parsedUrl, err := urlnet.Parse(proxyUrl)
+		if err != nil {
+			var urlError *urlnet.Error
+			if errors.As(err, &urlError) {
+				urlError.URL = withoutUserinfo(urlError.URL)
+			}
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if parsedUrl.Scheme == "" || parsedUrl.Host == "" {
+			return fmt.Errorf("invalid proxy URL %v, scheme and host are required", RedactSensitiveURL(withoutUserinfo(proxyUrl)))
+		}
+		settings.proxy = http.ProxyURL(parsedUrl)
+		return nil
+	}
+}
+
+// withoutUserinfo removes the user:password@ part of rawUrl, also when it does not parse,
+// so proxy credentials are not written to errors and logs.
+func withoutUserinfo(rawUrl string) string {
+	prefix, authority := "", rawUrl
+	if scheme, rest, found := strings.Cut(rawUrl, "://"); found {
+		prefix, authority = scheme+"://", rest
+	}
+	suffix := ""
+	if end := strings.IndexAny(authority, "/?#"); end >= 0 {
+		authority, suffix = authority[:end], authority[end:]
+	}
+	if at := strings.LastIndex(authority, "@"); at >= 0 {
+		authority = authority[at+1:]
+	}
+	return prefix + authority + suffix
+}
+
+// WithProxyFromEnvironment uses the proxy set by HTTPS_PROXY and NO_PROXY.
+func WithProxyFromEnvironment() HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.proxy = http.ProxyFromEnvironment
+		return nil
+	}
+}
+
+// WithDialTimeout sets the time limit to open a connection.
+func WithDialTimeout(timeout time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.dialTimeout = timeout
+		return nil
+	}
+}
+
+// WithTLSHandshakeTimeout sets the time limit of the TLS handshake.
+func WithTLSHandshakeTimeout(timeout time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.tlsHandshakeTimeout = timeout
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept open.
+func WithIdleConnTimeout(timeout time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.idleConnTimeout = timeout
+		return nil
+	}
+}
+
+// WithResponseHeaderTimeout sets the time limit to receive the response headers once the request is sent.
+func WithResponseHeaderTimeout(timeout time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.responseHeaderTimeout = timeout
+		return nil
+	}
+}
+
+// WithMaxConnsPerHost limits the number of connections per host, zero means no limit.
+func WithMaxConnsPerHost(maxConns int) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if maxConns < 0 {
+			return errors.New("max connections per host must be greater than or equal to 0")
+		}
+		settings.maxConnsPerHost = maxConns
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections are kept per host.
+func WithMaxIdleConnsPerHost(maxIdleConns int) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if maxIdleConns < 0 {
+			return errors.New("max idle connections per host must be greater than or equal to 0")
+		}
+		settings.maxIdleConnsPerHost = maxIdleConns
+		return nil
+	}
+}
+
+// WithTLSMinVersion sets the minimum TLS version, tls.VersionTLS12 or tls.VersionTLS13.
+func WithTLSMinVersion(version uint16) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if version != tls.VersionTLS12 && version != tls.VersionTLS13 {
+			return fmt.Errorf("unsupported TLS min version %v, use TLS 1.2 or TLS 1.3", tls.VersionName(version))
+		}
+		settings.tlsMinVersion = version
+		return nil
+	}
+}
+
+// WithHTTP2 sets whether HTTP/2 is attempted, HTTP/1.1 is used by default.
+func WithHTTP2(enabled bool) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		settings.http2 = enabled
+		return nil
+	}
+}
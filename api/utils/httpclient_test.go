@@ -191,6 +191,10 @@ func TestCallSecretSafeAPIError400(t *testing.T) {
 		t.Errorf("Test case Failed")
 	}
 
+	if StatusCode(businessError) != http.StatusBadRequest || businessError.Error() != "error - status code: 400 - " {
+		t.Errorf("Test case Failed %v", businessError)
+	}
+
 }
 
 func TestCreateMultiPartRequest(t *testing.T) {
@@ -0,0 +1,179 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// privateKeyPEMTypes are the PEM block types accepted as client certificate keys:
+// PKCS #8, PKCS #1 RSA, SEC 1 EC and encrypted PKCS #8.
+var privateKeyPEMTypes = []string{"PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY", "ENCRYPTED PRIVATE KEY"}
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is the PKCS #8 EncryptedPrivateKeyInfo structure.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params are the RFC 8018 PBES2 parameters.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params are the RFC 8018 PBKDF2 parameters.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// IsEncryptedPrivateKeyPEM reports whether keyPEM is an encrypted PKCS #8 key.
+func IsEncryptedPrivateKeyPEM(keyPEM string) bool {
+	block, _ := pem.Decode([]byte(keyPEM))
+	return block != nil && block.Type == "ENCRYPTED PRIVATE KEY"
+}
+
+// DecryptPrivateKeyPEM decrypts an encrypted PKCS #8 key (PBES2 with PBKDF2 and AES-CBC)
+// and returns it as an unencrypted PKCS #8 PEM key. Unencrypted keys are returned unchanged.
+// Legacy encrypted PEM keys (Proc-Type headers) use an insecure key derivation and are
+// rejected, convert them with "openssl pkcs8 -topk8".
+func DecryptPrivateKeyPEM(keyPEM string, password string) (string, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return "", errors.New("invalid certificate key content, no PEM block found")
+	}
+
+	if strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return "", errors.New("legacy encrypted PEM keys are not supported, convert the key to encrypted PKCS #8 with openssl pkcs8 -topk8")
+	}
+	if block.Type != "ENCRYPTED PRIVATE KEY" {
+		return keyPEM, nil
+	}
+
+	der, err := decryptPKCS8(block.Bytes, []byte(password))
+	if err != nil {
+		return "", fmt.Errorf("decrypting certificate key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// decryptPKCS8 decrypts a DER EncryptedPrivateKeyInfo and returns the PKCS #8 key.
+func decryptPKCS8(der []byte, password []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm %v, only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, err
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function %v, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var keySize int
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keySize = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keySize = 24
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keySize = 32
+	default:
+		return nil, fmt.Errorf("unsupported encryption scheme %v, only AES-CBC is supported", params.EncryptionScheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid AES-CBC initialization vector")
+	}
+
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, err
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0 || kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA384):
+		prf = sha512.New384
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %v", kdfParams.PRF.Algorithm)
+	}
+
+	key, err := pbkdf2.Key(prf, string(password), kdfParams.Salt, kdfParams.IterationCount, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid encrypted key length")
+	}
+	blockCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(blockCipher, iv).CryptBlocks(decrypted, info.EncryptedData)
+
+	// PKCS #7 padding, a wrong password almost always produces invalid padding.
+	padding := int(decrypted[len(decrypted)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(decrypted[len(decrypted)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("incorrect password or invalid key")
+	}
+	decrypted = decrypted[:len(decrypted)-padding]
+
+	if _, err = x509.ParsePKCS8PrivateKey(decrypted); err != nil {
+		return nil, errors.New("incorrect password or invalid key")
+	}
+	return decrypted, nil
+}
+
+// hasPrivateKeyPEMType reports whether keyPEM starts and ends with the markers of one of privateKeyPEMTypes.
+func hasPrivateKeyPEMType(keyPEM string) bool {
+	for _, blockType := range privateKeyPEMTypes {
+		if strings.HasPrefix(keyPEM, "-----BEGIN "+blockType+"-----") && strings.HasSuffix(keyPEM, "-----END "+blockType+"-----") {
+			return true
+		}
+	}
+	return false
+}
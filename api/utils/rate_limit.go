@@ -0,0 +1,138 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxRetryAfter caps the wait requested by a Retry-After header when WithMaxRetryAfter is not used.
+const defaultMaxRetryAfter = time.Minute
+
+// rateLimiter is a token bucket: it holds up to burst tokens, refilled at rate
+// tokens per second, and every request takes one.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newRateLimiter returns a full bucket.
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// take takes a token and returns 0, or returns how long until a token is available
+// without taking one.
+func (limiter *rateLimiter) take() time.Duration {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	if !limiter.last.IsZero() {
+		limiter.tokens = math.Min(limiter.burst, limiter.tokens+now.Sub(limiter.last).Seconds()*limiter.rate)
+	}
+	limiter.last = now
+
+	if limiter.tokens >= 1 {
+		limiter.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - limiter.tokens) / limiter.rate * float64(time.Second)))
+}
+
+// WithRateLimit limits the client to requestsPerSecond on average, with bursts of up
+// to burst requests. A request over the budget is not sent and fails with a
+// RateLimitedError, which the retrying calls retry once a token is available.
+// The budget is shared by the copies of the client.
+func WithRateLimit(requestsPerSecond float64, burst int) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if requestsPerSecond <= 0 || math.IsInf(requestsPerSecond, 0) || math.IsNaN(requestsPerSecond) {
+			return errors.New("rate limit must be a positive number of requests per second")
+		}
+		if burst < 1 {
+			return errors.New("rate limit burst must be at least 1")
+		}
+		settings.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+		return nil
+	}
+}
+
+// WithMaxRetryAfter caps the wait requested by Retry-After headers, one minute by default.
+// Longer waits are shortened to maxRetryAfter.
+func WithMaxRetryAfter(maxRetryAfter time.Duration) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if maxRetryAfter <= 0 {
+			return errors.New("max retry after must be greater than 0")
+		}
+		settings.maxRetryAfter = maxRetryAfter
+		return nil
+	}
+}
+
+// parseRetryAfter returns the wait of a Retry-After header, in seconds or as an
+// HTTP date, 0 when it is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// RetryAfter returns the wait requested by the server or the rate limiter for err, 0 when there is none.
+func RetryAfter(err error) time.Duration {
+	var rateLimitedError *RateLimitedError
+	if errors.As(err, &rateLimitedError) {
+		return rateLimitedError.RetryAfter
+	}
+	var statusCodeError *StatusCodeError
+	if errors.As(err, &statusCodeError) {
+		return statusCodeError.RetryAfter
+	}
+	return 0
+}
+
+// waitRetryAfter waits until the Retry-After of err has elapsed, backoff already
+// waits the given wait. It returns early when the client context is done.
+func (client *HttpClientObj) waitRetryAfter(err error, wait time.Duration) {
+	retryAfter := RetryAfter(err)
+	maxRetryAfter := client.maxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = defaultMaxRetryAfter
+	}
+	retryAfter = min(retryAfter, maxRetryAfter)
+	if retryAfter <= wait {
+		return
+	}
+
+	timer := time.NewTimer(retryAfter - wait)
+	defer timer.Stop()
+	select {
+	case <-resolveContext(client.Context).Done():
+	case <-timer.C:
+	}
+}
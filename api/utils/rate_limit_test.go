@@ -0,0 +1,181 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+// Unit tests for utils package.
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+)
+
+func rateLimitBackoff(maxElapsedTime time.Duration) *backoff.ExponentialBackOff {
+	backoffDefinition := backoff.NewExponentialBackOff()
+	backoffDefinition.InitialInterval = 10 * time.Millisecond
+	backoffDefinition.MaxElapsedTime = maxElapsedTime
+	return backoffDefinition
+}
+
+func rateLimitRequest(url string) *entities.CallSecretSafeAPIObj {
+	return &entities.CallSecretSafeAPIObj{
+		Url:         url,
+		HttpMethod:  "GET",
+		Body:        bytes.Buffer{},
+		Method:      "GetSecret",
+		ContentType: "application/json",
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := map[string]time.Duration{
+		"":                              0,
+		"3":                             3 * time.Second,
+		" 120 ":                         2 * time.Minute,
+		"-1":                            0,
+		"soon":                          0,
+		"Fri, 02 Jan 2026 03:04:15 GMT": 10 * time.Second,
+		"Fri, 02 Jan 2026 03:04:00 GMT": 0,
+	}
+	for header, expected := range testCases {
+		if retryAfter := parseRetryAfter(header, now); retryAfter != expected {
+			t.Errorf("Test case Failed for %q: %v, expected %v", header, retryAfter, expected)
+		}
+	}
+}
+
+func TestRateLimiterTake(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	limiter := newRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+
+	if limiter.take() != 0 || limiter.take() != 0 {
+		t.Fatal("Test case Failed, the burst must be available")
+	}
+	if wait := limiter.take(); wait != time.Second {
+		t.Errorf("Test case Failed, wait %v", wait)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if wait := limiter.take(); wait != 500*time.Millisecond {
+		t.Errorf("Test case Failed, wait %v", wait)
+	}
+
+	now = now.Add(10 * time.Second)
+	if limiter.take() != 0 || limiter.take() != 0 || limiter.take() == 0 {
+		t.Error("Test case Failed, the bucket must refill up to the burst only")
+	}
+}
+
+func TestRateLimitOptions(t *testing.T) {
+	zapLogger := logging.NewZapLogger(zap.NewNop())
+
+	for name, option := range map[string]HttpClientOption{
+		"zero rate":        WithRateLimit(0, 1),
+		"zero burst":       WithRateLimit(1, 0),
+		"zero retry after": WithMaxRetryAfter(0),
+	} {
+		if _, err := NewHttpClient(zapLogger, option); err == nil {
+			t.Errorf("Test case Failed, %v must fail", name)
+		}
+	}
+}
+
+func TestMakeRequestRetriesRateLimitedResponses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`"secret"`))
+		}
+	}))
+	defer server.Close()
+
+	httpClientObj, _ := NewHttpClient(logging.NewZapLogger(zap.NewNop()))
+
+	start := time.Now()
+	response, err := httpClientObj.MakeRequest(rateLimitRequest(server.URL), rateLimitBackoff(10*time.Second))
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+	if string(response) != `"secret"` || calls.Load() != 3 {
+		t.Errorf("Test case Failed %v after %v calls", string(response), calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Test case Failed, Retry-After was not honored, retried after %v", elapsed)
+	}
+}
+
+func TestMakeRequestRateLimitedByServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`"Too many requests"`))
+	}))
+	defer server.Close()
+
+	httpClientObj, _ := NewHttpClient(logging.NewZapLogger(zap.NewNop()), WithMaxRetryAfter(20*time.Millisecond))
+
+	_, err := httpClientObj.MakeRequest(rateLimitRequest(server.URL), rateLimitBackoff(100*time.Millisecond))
+
+	var rateLimitedError *RateLimitedError
+	if !errors.As(err, &rateLimitedError) {
+		t.Fatalf("Test case Failed, expected a RateLimitedError: %v", err)
+	}
+	if rateLimitedError.RetryAfter != time.Hour || StatusCode(err) != http.StatusTooManyRequests {
+		t.Errorf("Test case Failed %v", err)
+	}
+	expected := `rate limited by server, retry after 1h0m0s: error - status code: 429 - "Too many requests"`
+	if err.Error() != expected {
+		t.Errorf("Test case Failed %v, %v", err.Error(), expected)
+	}
+}
+
+func TestMakeRequestClientRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`"secret"`))
+	}))
+	defer server.Close()
+
+	httpClientObj, _ := NewHttpClient(logging.NewZapLogger(zap.NewNop()), WithRateLimit(10, 1))
+	// Copies of the client share the budget.
+	httpClientCopy := *httpClientObj
+
+	if _, _, technicalError, _ := httpClientObj.HttpRequest(server.URL, "GET", bytes.Buffer{}, "", "", "application/json", ""); technicalError != nil {
+		t.Fatalf("Test case Failed: %v", technicalError)
+	}
+
+	_, _, technicalError, _ := httpClientCopy.HttpRequest(server.URL, "GET", bytes.Buffer{}, "", "", "application/json", "")
+	var rateLimitedError *RateLimitedError
+	if !errors.As(technicalError, &rateLimitedError) || rateLimitedError.Err != nil || rateLimitedError.RetryAfter <= 0 {
+		t.Fatalf("Test case Failed, expected a client RateLimitedError: %v", technicalError)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Test case Failed, a rate limited request must not be sent, %v calls", calls.Load())
+	}
+
+	// The retrying calls wait for the budget.
+	if _, err := httpClientObj.MakeRequest(rateLimitRequest(server.URL), rateLimitBackoff(5*time.Second)); err != nil {
+		t.Errorf("Test case Failed: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Test case Failed, %v calls", calls.Load())
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryMode selects which technical errors of a call are retried.
+type RetryMode int
+
+const (
+	// RetryModeDefault retries like RetryModeIdempotent for idempotent methods, see
+	// IsIdempotent, and like RetryModeUnsent for the others.
+	RetryModeDefault RetryMode = iota
+	// RetryModeIdempotent retries every technical error, the call may be repeated.
+	RetryModeIdempotent
+	// RetryModeUnsent retries only errors raised before the server could process the
+	// call: connection failures and rate limiting.
+	RetryModeUnsent
+	// RetryModeNever never retries.
+	RetryModeNever
+)
+
+// idempotentPostMethods are the POST methods that can be repeated safely.
+var idempotentPostMethods = map[string]bool{
+	constants.GetToken:  true,
+	constants.SignAppin: true,
+	constants.SignOut:   true,
+}
+
+// RetryPolicy decides which failed calls are retried. Business errors (4xx
+// responses) are never retried. The zero value is the default policy.
+type RetryPolicy struct {
+	// MaxAttempts caps the attempts of a call, the first one included. 0 means no
+	// cap, the backoff MaxElapsedTime still applies.
+	MaxAttempts int
+	// Operations overrides the policy of methods, keyed by the constants method names.
+	Operations map[string]OperationRetryPolicy
+}
+
+// OperationRetryPolicy overrides the RetryPolicy of a method.
+type OperationRetryPolicy struct {
+	Mode RetryMode
+	// MaxAttempts overrides RetryPolicy.MaxAttempts when not 0, 1 disables retries.
+	MaxAttempts int
+}
+
+// AttemptObserver is implemented by request observers that also record how many
+// attempts each retried call took, see HttpClientObj.SetRequestObserver.
+type AttemptObserver interface {
+	// ObserveAttempts is called once per call with the method name and the number of attempts.
+	ObserveAttempts(method string, attempts int)
+}
+
+// IsIdempotent reports whether a call of method (see the constants package) with
+// httpMethod can be repeated without changing the result: GET, PUT and DELETE
+// calls and the authentication calls.
+func IsIdempotent(method string, httpMethod string) bool {
+	switch httpMethod {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return idempotentPostMethods[method]
+}
+
+// validate checks the attempts and modes of policy.
+func (policy RetryPolicy) validate() error {
+	if policy.MaxAttempts < 0 {
+		return errors.New("retry max attempts must be greater than or equal to 0")
+	}
+	for method, operation := range policy.Operations {
+		if operation.MaxAttempts < 0 {
+			return fmt.Errorf("retry max attempts of %v must be greater than or equal to 0", method)
+		}
+		if operation.Mode < RetryModeDefault || operation.Mode > RetryModeNever {
+			return fmt.Errorf("invalid retry mode %v of %v", operation.Mode, method)
+		}
+	}
+	return nil
+}
+
+// operation returns the mode, resolved from the method when it is the default, and the max attempts of a call.
+func (policy RetryPolicy) operation(method string, httpMethod string) (RetryMode, int) {
+	operation := policy.Operations[method]
+	if operation.MaxAttempts == 0 {
+		operation.MaxAttempts = policy.MaxAttempts
+	}
+	if operation.Mode == RetryModeDefault {
+		operation.Mode = RetryModeUnsent
+		if IsIdempotent(method, httpMethod) {
+			operation.Mode = RetryModeIdempotent
+		}
+	}
+	return operation.Mode, operation.MaxAttempts
+}
+
+// retryable reports whether err may be retried in mode, an open circuit is never retried.
+func retryable(mode RetryMode, err error) bool {
+	var circuitOpenError *CircuitOpenError
+	if errors.As(err, &circuitOpenError) {
+		return false
+	}
+	switch mode {
+	case RetryModeIdempotent:
+		return true
+	case RetryModeUnsent:
+		return isUnsentError(err)
+	}
+	return false
+}
+
+// isUnsentError reports whether err was raised before the server could process the request.
+func isUnsentError(err error) bool {
+	var rateLimitedError *RateLimitedError
+	if errors.As(err, &rateLimitedError) {
+		return true
+	}
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// WithRetryPolicy sets the retry policy of the client, see RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) HttpClientOption {
+	return func(settings *httpClientSettings) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		settings.retryPolicy = policy
+		return nil
+	}
+}
+
+// SetRetryPolicy sets the retry policy of the client. Like SetRequestObserver, it
+// must be called before the client is passed to authentication.Authenticate.
+func (client *HttpClientObj) SetRetryPolicy(policy RetryPolicy) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	client.retryPolicy = policy
+	return nil
+}
+
+// Retry runs operation, the API call described by callSecretSafeAPIObj, until it
+// returns nil or an error the retry policy does not retry, waiting between the
+// attempts as exponentialBackOff and Retry-After headers require.
+func (client *HttpClientObj) Retry(callSecretSafeAPIObj *entities.CallSecretSafeAPIObj, exponentialBackOff *backoff.ExponentialBackOff, operation func() error) error {
+	mode, maxAttempts := client.retryPolicy.operation(callSecretSafeAPIObj.Method, callSecretSafeAPIObj.HttpMethod)
+
+	var backOff backoff.BackOff = exponentialBackOff
+	if maxAttempts > 0 {
+		backOff = backoff.WithMaxRetries(exponentialBackOff, uint64(maxAttempts-1))
+	}
+
+	attempts := 0
+	err := backoff.RetryNotify(func() error {
+		attempts++
+		err := operation()
+		if err != nil && !retryable(mode, err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backOff, client.NotifyRetry(callSecretSafeAPIObj.Method))
+
+	if attempts > 1 {
+		logging.Structured(client.log).DebugFields("request attempts", logging.String("method", callSecretSafeAPIObj.Method), logging.Int("attempts", attempts))
+	}
+	if attemptObserver, ok := client.observer.(AttemptObserver); ok {
+		attemptObserver.ObserveAttempts(callSecretSafeAPIObj.Method, attempts)
+	}
+	return err
+}
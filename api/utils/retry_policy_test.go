@@ -0,0 +1,169 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+// Unit tests for utils package.
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	logging "github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+	"go.uber.org/zap"
+)
+
+// attemptRecorder records the attempts reported to an AttemptObserver.
+type attemptRecorder struct {
+	attempts map[string]int
+}
+
+func (recorder *attemptRecorder) ObserveRequest(string, int, time.Duration) {}
+
+func (recorder *attemptRecorder) ObserveRetry(string) {}
+
+func (recorder *attemptRecorder) ObserveAttempts(method string, attempts int) {
+	recorder.attempts[method] = attempts
+}
+
+func TestIsIdempotent(t *testing.T) {
+	testCases := []struct {
+		method     string
+		httpMethod string
+		idempotent bool
+	}{
+		{constants.SecretGetSecretByPath, http.MethodGet, true},
+		{constants.ManagedAccountRequestCheckIn, http.MethodPut, true},
+		{constants.DeleteAsset, http.MethodDelete, true},
+		{constants.GetToken, http.MethodPost, true},
+		{constants.SignOut, http.MethodPost, true},
+		{constants.ManagedAccountCreateRequest, http.MethodPost, false},
+		{constants.CreateAsset, http.MethodPost, false},
+	}
+	for _, testCase := range testCases {
+		if IsIdempotent(testCase.method, testCase.httpMethod) != testCase.idempotent {
+			t.Errorf("Test case Failed for %v %v, expected %v", testCase.httpMethod, testCase.method, testCase.idempotent)
+		}
+	}
+}
+
+func TestIsUnsentError(t *testing.T) {
+	dialError := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	readError := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "read", Err: errors.New("connection reset")}}
+
+	if !isUnsentError(dialError) || !isUnsentError(&RateLimitedError{}) {
+		t.Error("Test case Failed, dial errors and rate limits must be unsent")
+	}
+	if isUnsentError(readError) || isUnsentError(&StatusCodeError{StatusCode: http.StatusInternalServerError}) {
+		t.Error("Test case Failed, read errors and server errors may have been processed")
+	}
+}
+
+func TestIsTechnicalError(t *testing.T) {
+	dialError := &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	technicalErrors := []error{
+		dialError,
+		&StatusCodeError{StatusCode: http.StatusBadGateway},
+		&StatusCodeError{StatusCode: http.StatusRequestTimeout},
+		&RateLimitedError{},
+		&CircuitOpenError{},
+	}
+	for _, err := range technicalErrors {
+		if !IsTechnicalError(err) {
+			t.Errorf("Test case Failed, %v must be technical", err)
+		}
+	}
+
+	businessErrors := []error{nil, errors.New("not found"), &StatusCodeError{StatusCode: http.StatusNotFound}, &StatusCodeError{StatusCode: http.StatusUnauthorized}}
+	for _, err := range businessErrors {
+		if IsTechnicalError(err) {
+			t.Errorf("Test case Failed, %v must not be technical", err)
+		}
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     RetryPolicy
+		httpMethod string
+		method     string
+		statuses   []int
+		calls      int32
+		failed     bool
+	}{
+		{"idempotent retried", RetryPolicy{}, http.MethodGet, constants.SecretGetSecretByPath, []int{500, 502, 200}, 3, false},
+		{"create not repeated", RetryPolicy{}, http.MethodPost, constants.ManagedAccountCreateRequest, []int{500, 200}, 1, true},
+		{"create rate limited retried", RetryPolicy{}, http.MethodPost, constants.ManagedAccountCreateRequest, []int{429, 200}, 2, false},
+		{"business error not retried", RetryPolicy{}, http.MethodGet, constants.SecretGetSecretByPath, []int{404, 200}, 1, true},
+		{"max attempts", RetryPolicy{MaxAttempts: 2}, http.MethodGet, constants.SecretGetSecretByPath, []int{500, 500, 200}, 2, true},
+		{"operation override", RetryPolicy{
+			MaxAttempts: 2,
+			Operations:  map[string]OperationRetryPolicy{constants.ManagedAccountCreateRequest: {Mode: RetryModeIdempotent, MaxAttempts: 3}},
+		}, http.MethodPost, constants.ManagedAccountCreateRequest, []int{500, 500, 200}, 3, false},
+		{"never", RetryPolicy{
+			Operations: map[string]OperationRetryPolicy{constants.SecretGetSecretByPath: {Mode: RetryModeNever}},
+		}, http.MethodGet, constants.SecretGetSecretByPath, []int{500, 200}, 1, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1))
+				w.WriteHeader(testCase.statuses[min(call, len(testCase.statuses))-1])
+			}))
+			defer server.Close()
+
+			recorder := &attemptRecorder{attempts: map[string]int{}}
+			httpClientObj, err := NewHttpClient(logging.NewZapLogger(zap.NewNop()), WithRetryPolicy(testCase.policy))
+			if err != nil {
+				t.Fatalf("Test case Failed: %v", err)
+			}
+			httpClientObj.SetRequestObserver(recorder)
+
+			callSecretSafeAPIObj := &entities.CallSecretSafeAPIObj{
+				Url:         server.URL,
+				HttpMethod:  testCase.httpMethod,
+				Body:        bytes.Buffer{},
+				Method:      testCase.method,
+				ContentType: "application/json",
+			}
+			_, err = httpClientObj.MakeRequest(callSecretSafeAPIObj, rateLimitBackoff(5*time.Second))
+
+			if (err != nil) != testCase.failed {
+				t.Errorf("Test case Failed, error %v", err)
+			}
+			if calls.Load() != testCase.calls || recorder.attempts[testCase.method] != int(testCase.calls) {
+				t.Errorf("Test case Failed, %v calls and %v recorded attempts, expected %v", calls.Load(), recorder.attempts[testCase.method], testCase.calls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyValidation(t *testing.T) {
+	httpClientObj, _ := NewHttpClient(logging.NewZapLogger(zap.NewNop()))
+
+	invalidPolicies := []RetryPolicy{
+		{MaxAttempts: -1},
+		{Operations: map[string]OperationRetryPolicy{constants.CreateAsset: {MaxAttempts: -1}}},
+		{Operations: map[string]OperationRetryPolicy{constants.CreateAsset: {Mode: RetryMode(10)}}},
+	}
+	for _, policy := range invalidPolicies {
+		if err := httpClientObj.SetRetryPolicy(policy); err == nil {
+			t.Errorf("Test case Failed, %+v must fail", policy)
+		}
+	}
+
+	if err := httpClientObj.SetRetryPolicy(RetryPolicy{MaxAttempts: 5}); err != nil || httpClientObj.retryPolicy.MaxAttempts != 5 {
+		t.Errorf("Test case Failed: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+package utils
+
+import (
+	"errors"
+	"sync"
+)
+
+// flightCall is a call in flight, done is closed once value and err are set.
+type flightCall[T any] struct {
+	done    chan struct{}
+	waiters int
+	value   T
+	err     error
+}
+
+// SingleFlight collapses identical concurrent calls into one: while a call for a
+// key is in flight, callers asking for the same key wait for it and share its
+// result instead of calling again. Results are not cached, the next call after it
+// returns calls again. The zero value is ready to use and must not be copied.
+type SingleFlight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+// Do calls fn, or waits for the call for key in flight, and returns its result.
+// shared is true when the result was returned to several callers.
+func (flight *SingleFlight[T]) Do(key string, fn func() (T, error)) (value T, shared bool, err error) {
+	flight.mu.Lock()
+	if call, ok := flight.calls[key]; ok {
+		call.waiters++
+		flight.mu.Unlock()
+		<-call.done
+		return call.value, true, call.err
+	}
+	if flight.calls == nil {
+		flight.calls = map[string]*flightCall[T]{}
+	}
+	// The error is kept if fn panics, the panic goes on in the calling goroutine only.
+	call := &flightCall[T]{done: make(chan struct{}), err: errors.New("shared call panicked")}
+	flight.calls[key] = call
+	flight.mu.Unlock()
+
+	defer func() {
+		flight.mu.Lock()
+		delete(flight.calls, key)
+		shared = call.waiters > 0
+		flight.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+	return call.value, false, call.err
+}
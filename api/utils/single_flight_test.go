@@ -0,0 +1,106 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// utils responsible for utility functions.
+// Unit tests for utils package.
+package utils
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSingleFlight(t *testing.T) {
+	var flight SingleFlight[string]
+	var calls atomic.Int32
+	var sharedResults atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	fn := func() (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return "value", nil
+	}
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		if _, shared, _ := flight.Do("key", fn); shared {
+			sharedResults.Add(1)
+		}
+	}()
+	<-started
+
+	for range 10 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			value, shared, err := flight.Do("key", fn)
+			if value != "value" || err != nil {
+				t.Errorf("Test case Failed %v %v", value, err)
+			}
+			if shared {
+				sharedResults.Add(1)
+			}
+		}()
+	}
+	// Wait for the callers to join the call in flight.
+	for {
+		flight.mu.Lock()
+		waiters := flight.calls["key"].waiters
+		flight.mu.Unlock()
+		if waiters == 10 {
+			break
+		}
+	}
+	close(release)
+	waitGroup.Wait()
+
+	if calls.Load() != 1 || sharedResults.Load() != 11 {
+		t.Errorf("Test case Failed, %v calls and %v shared results", calls.Load(), sharedResults.Load())
+	}
+
+	// Results are not cached.
+	if _, shared, _ := flight.Do("key", fn); shared || calls.Load() != 2 {
+		t.Errorf("Test case Failed, %v calls", calls.Load())
+	}
+}
+
+func TestSingleFlightErrorsAndKeys(t *testing.T) {
+	var flight SingleFlight[int]
+	expectedError := errors.New("failed")
+
+	if _, _, err := flight.Do("a", func() (int, error) { return 0, expectedError }); !errors.Is(err, expectedError) {
+		t.Errorf("Test case Failed: %v", err)
+	}
+
+	// Keys are independent.
+	value, _, err := flight.Do("a", func() (int, error) {
+		inner, _, err := flight.Do("b", func() (int, error) { return 2, nil })
+		return inner + 1, err
+	})
+	if value != 3 || err != nil {
+		t.Errorf("Test case Failed %v %v", value, err)
+	}
+}
+
+func TestSingleFlightPanic(t *testing.T) {
+	var flight SingleFlight[int]
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Test case Failed, the panic must go on")
+			}
+		}()
+		_, _, _ = flight.Do("key", func() (int, error) { panic("boom") })
+	}()
+
+	if value, _, err := flight.Do("key", func() (int, error) { return 1, nil }); value != 1 || err != nil {
+		t.Errorf("Test case Failed %v %v", value, err)
+	}
+}
@@ -298,7 +298,7 @@ func TestValidateCertificateInfo(t *testing.T) {
 	params.CertificateKey = constants.FakecertificateKey
 
 	err = ValidateCertificateInfo(params)
-	expetedErrorMessage = "invalid length for certificate, the maximum size is 32768 bits"
+	expetedErrorMessage = "invalid length for certificate, the maximum size is 131072 bits"
 	if err.Error() != expetedErrorMessage {
 		t.Errorf("Test case Failed %v, %v", err.Error(), expetedErrorMessage)
 	}
@@ -14,8 +14,6 @@ import (
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
 
 	"github.com/BeyondTrust/go-client-library-passwordsafe/api/constants"
-
-	backoff "github.com/cenkalti/backoff/v4"
 )
 
 type WorkGroupObj struct {
@@ -88,10 +86,10 @@ func (workGroupObj *WorkGroupObj) createWorkGroup(workGroup entities.WorkGroupDe
 		ApiVersion:  "",
 	}
 
-	technicalError = backoff.Retry(func() error {
+	technicalError = workGroupObj.authenticationObj.HttpClient.Retry(callSecretSafeAPIObj, workGroupObj.authenticationObj.ExponentialBackOff, func() error {
 		body, _, technicalError, businessError = workGroupObj.authenticationObj.HttpClient.CallSecretSafeAPI(*callSecretSafeAPIObj)
 		return technicalError
-	}, workGroupObj.authenticationObj.ExponentialBackOff)
+	})
 
 	if technicalError != nil {
 		return entities.WorkGroupResponse{}, technicalError
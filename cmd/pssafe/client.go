@@ -0,0 +1,67 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"io"
+	"log"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/authentication"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+)
+
+// cliContext is shared by the commands of one invocation. The session is
+// opened on first use and signed out by close.
+type cliContext struct {
+	config         config
+	stdin          io.Reader
+	stdout         io.Writer
+	stderr         io.Writer
+	log            *logging.RedactingLogger
+	authentication *authentication.AuthenticationObj
+}
+
+func newCliContext(config config, stdin io.Reader, stdout io.Writer, stderr io.Writer) *cliContext {
+	output := io.Discard
+	if config.Verbose {
+		output = stderr
+	}
+
+	return &cliContext{
+		config: config,
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		log:    logging.NewRedactingLogger(logging.NewLogLogger(log.New(output, "", log.LstdFlags))),
+	}
+}
+
+// session validates the configuration, signs in and returns the authentication object.
+func (cli *cliContext) session() (*authentication.AuthenticationObj, error) {
+	if cli.authentication != nil {
+		return cli.authentication, nil
+	}
+
+	authenticationObj, err := cli.config.NewAuthenticationObj(cli.log)
+	if err != nil {
+		return nil, configError{err}
+	}
+
+	if _, err = authenticationObj.GetPasswordSafeAuthentication(); err != nil {
+		return nil, authenticationError{err}
+	}
+
+	cli.authentication = authenticationObj
+	return authenticationObj, nil
+}
+
+// close signs out when a session was opened.
+func (cli *cliContext) close() {
+	if cli.authentication == nil {
+		return
+	}
+	if err := cli.authentication.SignOut(); err != nil {
+		cli.log.Error(err.Error())
+	}
+	cli.authentication = nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"strconv"
+
+	managed_accounts "github.com/BeyondTrust/go-client-library-passwordsafe/api/managed_account"
+)
+
+func init() {
+	register("account", "get", "account get <system/account>...", "retrieve managed account passwords", accountGet)
+	register("account", "checkout", "account checkout <system/account>", "retrieve a password and keep the request open", accountCheckout)
+	register("account", "checkin", "account checkin <request-id>", "check in a request opened by checkout", accountCheckIn)
+	register("account", "rotate", "account rotate [--queue] <system/account>", "change a managed account password", accountRotate)
+	register("account", "ls", "account ls", "list managed accounts", accountList)
+	register("account", "delete", "account delete <account-id>", "delete a managed account", accountDelete)
+}
+
+// managedAccountObj returns a ManagedAccountstObj for the current session.
+func (cli *cliContext) managedAccountObj() (*managed_accounts.ManagedAccountstObj, error) {
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return nil, err
+	}
+	return managed_accounts.NewManagedAccountObj(*authenticationObj, cli.log)
+}
+
+func accountGet(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "account get <system/account>...")
+	paths, err := parseCommandFlags(flags, args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+
+	values, err := managedAccountObj.GetSecrets(paths, cli.config.Separator)
+	if len(values) > 0 {
+		if printErr := cli.printSecretValues(paths, values); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
+
+// checkoutOutput is printed by account checkout, unlike the library type it includes the password.
+type checkoutOutput struct {
+	SystemName  string
+	AccountName string
+	AccountId   int
+	RequestId   string
+	Password    string
+}
+
+func accountCheckout(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "account checkout <system/account>")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+
+	checkout, err := managedAccountObj.ManageAccountCheckoutFlow(positional[0], cli.config.Separator)
+	if err != nil {
+		return err
+	}
+
+	output := checkoutOutput{
+		SystemName:  checkout.SystemName,
+		AccountName: checkout.AccountName,
+		AccountId:   checkout.AccountId,
+		RequestId:   checkout.RequestId,
+		Password:    checkout.Password,
+	}
+	return cli.print(output, "SystemName", "AccountName", "AccountId", "RequestId", "Password")
+}
+
+func accountCheckIn(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "account checkin <request-id>")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+	return managedAccountObj.ManageAccountCheckInFlow(positional[0])
+}
+
+func accountRotate(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "account rotate [--queue] <system/account>")
+	queue := flags.Bool("queue", false, "queue the change instead of changing the password immediately")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+	return managedAccountObj.ManageAccountRotateFlow(positional[0], cli.config.Separator, *queue)
+}
+
+func accountList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "account ls")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := managedAccountObj.GetManagedAccountsListFlow()
+	if err != nil {
+		return err
+	}
+	return cli.print(accounts, "SystemId", "SystemName", "AccountId", "AccountName", "DomainName")
+}
+
+func accountDelete(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "account delete <account-id>")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	accountId, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+	return managedAccountObj.DeleteManagedAccountById(accountId)
+}
+
+// parseId parses a numeric ID argument.
+func parseId(value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, usageError{strconv.ErrSyntax}
+	}
+	return id, nil
+}
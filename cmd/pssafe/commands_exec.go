@@ -0,0 +1,233 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	psconfig "github.com/BeyondTrust/go-client-library-passwordsafe/api/config"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+const execUsage = "exec [--secret VAR=folder/title]... [--account VAR=system/account]... -- <command> [args]"
+
+func init() {
+	register("exec", "", execUsage, "run a command with secrets and passwords in its environment", execCommand)
+}
+
+// envMapping maps an environment variable to a secret or managed account path.
+type envMapping struct {
+	name string
+	path string
+}
+
+// envMappings collects repeated VAR=path flags.
+type envMappings []envMapping
+
+func (mappings *envMappings) String() string {
+	names := make([]string, 0, len(*mappings))
+	for _, mapping := range *mappings {
+		names = append(names, mapping.name)
+	}
+	return strings.Join(names, ",")
+}
+
+func (mappings *envMappings) Set(value string) error {
+	name, path, found := strings.Cut(value, "=")
+	if !found || name == "" || path == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("expected VAR=path, got %q", value)
+	}
+	*mappings = append(*mappings, envMapping{name: name, path: path})
+	return nil
+}
+
+// execCommand retrieves the mapped secrets and managed account passwords and runs
+// the command with them added to its environment. Managed account requests are held
+// open while the command runs and checked in when it exits, also when pssafe is
+// interrupted, the signal is forwarded to the command.
+func execCommand(cli *cliContext, args []string) (err error) {
+	flags := newCommandFlags(cli, execUsage)
+	var secretMappings, accountMappings envMappings
+	flags.Var(&secretMappings, "secret", "set VAR to the secret folder/title, can be repeated")
+	flags.Var(&accountMappings, "account", "set VAR to the password of system/account, can be repeated")
+	command, err := parseCommandFlags(flags, args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	for _, mapping := range append(append(envMappings{}, secretMappings...), accountMappings...) {
+		if names[mapping.name] {
+			return usageError{fmt.Errorf("variable %v is mapped more than once", mapping.name)}
+		}
+		names[mapping.name] = true
+	}
+
+	// Signals are caught from the start, so requests already checked out are checked in.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	env, checkouts, err := cli.execEnvironment(secretMappings, accountMappings)
+	defer func() {
+		if checkInErr := cli.checkIn(checkouts); checkInErr != nil {
+			if err == nil {
+				err = checkInErr
+			} else {
+				fmt.Fprintln(cli.stderr, "error:", checkInErr)
+			}
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	// The requests stay open without a session, a new one is opened to check them in.
+	cli.close()
+
+	select {
+	case received := <-signals:
+		return fmt.Errorf("received %v before starting %v", received, command[0])
+	default:
+	}
+
+	child := exec.Command(command[0], command[1:]...)
+	child.Env = append(childEnvironment(os.Environ()), env...)
+	child.Stdin = cli.stdin
+	child.Stdout = cli.stdout
+	child.Stderr = cli.stderr
+	if err = child.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- child.Wait()
+	}()
+
+	for {
+		select {
+		case received := <-signals:
+			_ = child.Process.Signal(received)
+		case err = <-done:
+			var exitError *exec.ExitError
+			if errors.As(err, &exitError) {
+				code := exitError.ExitCode()
+				if code < 0 {
+					code = ExitError
+				}
+				return childExitError{code: code}
+			}
+			return err
+		}
+	}
+}
+
+// credentialVariables are the environment variables holding the Password Safe credentials of pssafe,
+// they are not passed on to the command.
+var credentialVariables = []string{
+	psconfig.EnvClientSecret,
+	psconfig.EnvApiKey,
+	psconfig.EnvCertificateKey,
+	psconfig.EnvCertificateKeyPassword,
+	psconfig.EnvCertificatePassword,
+}
+
+// childEnvironment returns the entries of environ without the credential variables.
+func childEnvironment(environ []string) []string {
+	var env []string
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if !slices.Contains(credentialVariables, name) {
+			env = append(env, entry)
+		}
+	}
+	return env
+}
+
+// execEnvironment returns the VAR=value entries for the mappings and the managed
+// account requests it opened, also when it fails part way.
+func (cli *cliContext) execEnvironment(secretMappings envMappings, accountMappings envMappings) ([]string, []entities.ManagedAccountCheckout, error) {
+	var env []string
+
+	if len(secretMappings) > 0 {
+		secretObj, err := cli.secretObj(true)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		paths := make([]string, 0, len(secretMappings))
+		for _, mapping := range secretMappings {
+			paths = append(paths, mapping.path)
+		}
+
+		values, err := secretObj.GetSecrets(paths, cli.config.Separator)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, mapping := range secretMappings {
+			value, ok := values[mapping.path]
+			if !ok {
+				return nil, nil, utils.NewNotFoundError("secret %v not found", mapping.path)
+			}
+			cli.log.Register(value)
+			env = append(env, mapping.name+"="+value)
+		}
+	}
+
+	var checkouts []entities.ManagedAccountCheckout
+	if len(accountMappings) > 0 {
+		managedAccountObj, err := cli.managedAccountObj()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// An account mapped to several variables is checked out once.
+		passwords := map[string]string{}
+		for _, mapping := range accountMappings {
+			password, ok := passwords[mapping.path]
+			if !ok {
+				checkout, err := managedAccountObj.ManageAccountCheckoutFlow(mapping.path, cli.config.Separator)
+				if err != nil {
+					return nil, checkouts, err
+				}
+				checkouts = append(checkouts, checkout)
+				password = checkout.Password
+				passwords[mapping.path] = password
+				cli.log.Register(password)
+			}
+			env = append(env, mapping.name+"="+password)
+		}
+	}
+
+	return env, checkouts, nil
+}
+
+// checkIn checks in the requests opened by execEnvironment.
+func (cli *cliContext) checkIn(checkouts []entities.ManagedAccountCheckout) error {
+	if len(checkouts) == 0 {
+		return nil
+	}
+
+	managedAccountObj, err := cli.managedAccountObj()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, checkout := range checkouts {
+		if err = managedAccountObj.ManageAccountCheckInFlow(checkout.RequestId); err != nil {
+			errs = append(errs, fmt.Errorf("checking in request %v of %v%v%v: %w", checkout.RequestId, checkout.SystemName, cli.config.Separator, checkout.AccountName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
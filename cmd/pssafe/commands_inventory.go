@@ -0,0 +1,244 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"errors"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/assets"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/functional_accounts"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/managed_systems"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/platforms"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/workgroups"
+)
+
+func init() {
+	register("asset", "ls", "asset ls --workgroup-id <id> | --workgroup-name <name>", "list the assets of a workgroup", assetList)
+	register("asset", "create", "asset create --workgroup-name <name> --ip <address> [flags]", "create an asset", assetCreate)
+	register("asset", "delete", "asset delete <asset-id>", "delete an asset", assetDelete)
+	register("workgroup", "ls", "workgroup ls", "list workgroups", workgroupList)
+	register("workgroup", "create", "workgroup create <name>", "create a workgroup", workgroupCreate)
+	register("managed-system", "ls", "managed-system ls", "list managed systems", managedSystemList)
+	register("managed-system", "delete", "managed-system delete <managed-system-id>", "delete a managed system", managedSystemDelete)
+	register("functional-account", "ls", "functional-account ls", "list functional accounts", functionalAccountList)
+	register("functional-account", "delete", "functional-account delete <functional-account-id>", "delete a functional account", functionalAccountDelete)
+	register("platform", "ls", "platform ls", "list platforms", platformList)
+}
+
+// assetObj returns an AssetObj for the current session.
+func (cli *cliContext) assetObj() (*assets.AssetObj, error) {
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return nil, err
+	}
+	return assets.NewAssetObj(*authenticationObj, cli.log)
+}
+
+func assetList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "asset ls --workgroup-id <id> | --workgroup-name <name>")
+	workgroupId := flags.String("workgroup-id", "", "workgroup ID")
+	workgroupName := flags.String("workgroup-name", "", "workgroup name")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+	if (*workgroupId == "") == (*workgroupName == "") {
+		return usageError{errors.New("one of --workgroup-id or --workgroup-name is required")}
+	}
+
+	assetObj, err := cli.assetObj()
+	if err != nil {
+		return err
+	}
+
+	var assetsList []entities.AssetResponse
+	if *workgroupId != "" {
+		assetsList, err = assetObj.GetAssetsListByWorkgroupIdFlow(*workgroupId)
+	} else {
+		assetsList, err = assetObj.GetAssetsListByWorkgroupNameFlow(*workgroupName)
+	}
+	if err != nil {
+		return err
+	}
+	return cli.print(assetsList, "AssetID", "AssetName", "IPAddress", "DnsName", "OperatingSystem")
+}
+
+func assetCreate(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "asset create --workgroup-name <name> --ip <address> [flags]")
+	workgroupId := flags.String("workgroup-id", "", "workgroup ID")
+	workgroupName := flags.String("workgroup-name", "", "workgroup name")
+	assetDetails := entities.AssetDetails{}
+	flags.StringVar(&assetDetails.IPAddress, "ip", "", "IP address")
+	flags.StringVar(&assetDetails.AssetName, "name", "", "asset name")
+	flags.StringVar(&assetDetails.DnsName, "dns-name", "", "DNS name")
+	flags.StringVar(&assetDetails.DomainName, "domain-name", "", "domain name")
+	flags.StringVar(&assetDetails.AssetType, "type", "", "asset type")
+	flags.StringVar(&assetDetails.OperatingSystem, "os", "", "operating system")
+	flags.StringVar(&assetDetails.Description, "description", "", "description")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+	if (*workgroupId == "") == (*workgroupName == "") {
+		return usageError{errors.New("one of --workgroup-id or --workgroup-name is required")}
+	}
+
+	assetObj, err := cli.assetObj()
+	if err != nil {
+		return err
+	}
+
+	var response entities.AssetResponse
+	if *workgroupId != "" {
+		response, err = assetObj.CreateAssetByworkgroupIDFlow(*workgroupId, assetDetails)
+	} else {
+		response, err = assetObj.CreateAssetByWorkGroupNameFlow(*workgroupName, assetDetails)
+	}
+	if err != nil {
+		return err
+	}
+	return cli.print(response, "AssetID", "AssetName", "IPAddress", "WorkgroupID")
+}
+
+func assetDelete(cli *cliContext, args []string) error {
+	return deleteById(cli, args, "asset delete <asset-id>", func(id int) error {
+		assetObj, err := cli.assetObj()
+		if err != nil {
+			return err
+		}
+		return assetObj.DeleteAssetById(id)
+	})
+}
+
+func workgroupList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "workgroup ls")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return err
+	}
+	workGroupObj, _ := workgroups.NewWorkGroupObj(*authenticationObj, cli.log)
+
+	workgroupsList, err := workGroupObj.GetWorkgroupListFlow()
+	if err != nil {
+		return err
+	}
+	return cli.print(workgroupsList, "ID", "Name", "OrganizationID")
+}
+
+func workgroupCreate(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "workgroup create <name>")
+	organizationId := flags.String("organization-id", "", "organization ID")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return err
+	}
+	workGroupObj, _ := workgroups.NewWorkGroupObj(*authenticationObj, cli.log)
+
+	response, err := workGroupObj.CreateWorkGroupFlow(entities.WorkGroupDetails{Name: positional[0], OrganizationID: *organizationId})
+	if err != nil {
+		return err
+	}
+	return cli.print(response, "ID", "Name", "OrganizationID")
+}
+
+func managedSystemList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "managed-system ls")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return err
+	}
+	managedSystemObj, _ := managed_systems.NewManagedSystem(*authenticationObj, cli.log)
+
+	managedSystems, err := managedSystemObj.GetManagedSystemsListFlow()
+	if err != nil {
+		return err
+	}
+	return cli.print(managedSystems, "ManagedSystemID", "HostName", "IPAddress", "AssetID", "WorkgroupID")
+}
+
+func managedSystemDelete(cli *cliContext, args []string) error {
+	return deleteById(cli, args, "managed-system delete <managed-system-id>", func(id int) error {
+		authenticationObj, err := cli.session()
+		if err != nil {
+			return err
+		}
+		managedSystemObj, _ := managed_systems.NewManagedSystem(*authenticationObj, cli.log)
+		return managedSystemObj.DeleteManagedSystemById(id)
+	})
+}
+
+func functionalAccountList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "functional-account ls")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return err
+	}
+	functionalAccountObj, _ := functional_accounts.NewFuncionalAccount(*authenticationObj, cli.log)
+
+	functionalAccounts, err := functionalAccountObj.GetFunctionalAccountsFlow()
+	if err != nil {
+		return err
+	}
+	return cli.print(functionalAccounts, "FunctionalAccountID", "PlatformID", "AccountName", "DomainName", "DisplayName")
+}
+
+func functionalAccountDelete(cli *cliContext, args []string) error {
+	return deleteById(cli, args, "functional-account delete <functional-account-id>", func(id int) error {
+		authenticationObj, err := cli.session()
+		if err != nil {
+			return err
+		}
+		functionalAccountObj, _ := functional_accounts.NewFuncionalAccount(*authenticationObj, cli.log)
+		return functionalAccountObj.DeleteFunctionalAccountById(id)
+	})
+}
+
+func platformList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "platform ls")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return err
+	}
+	platformObj, _ := platforms.NewPlatformObj(*authenticationObj, cli.log)
+
+	platformsList, err := platformObj.GetPlatformsListFlow()
+	if err != nil {
+		return err
+	}
+	return cli.print(platformsList, "PlatformID", "Name", "ShortName", "DefaultPort")
+}
+
+// deleteById parses the numeric ID argument and passes it to deleteFunc.
+func deleteById(cli *cliContext, args []string, usage string, deleteFunc func(id int) error) error {
+	flags := newCommandFlags(cli, usage)
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseId(positional[0])
+	if err != nil {
+		return err
+	}
+	return deleteFunc(id)
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/entities"
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/secrets"
+)
+
+func init() {
+	register("secret", "get", "secret get <path>...", "retrieve secrets by folder/title", secretGet)
+	register("secret", "create", "secret create --folder <folder> --title <title> [flags]", "create a credential, text or file secret", secretCreate)
+	register("secret", "delete", "secret delete <secret-id>", "delete a secret", secretDelete)
+	register("secret", "ls", "secret ls [folder]", "list secrets, without their values", secretList)
+	register("folder", "ls", "folder ls", "list folders", folderList)
+	register("folder", "create", "folder create --parent <folder> <name>", "create a folder", folderCreate)
+	register("folder", "delete", "folder delete <folder-id>", "delete a folder", folderDelete)
+	register("safe", "ls", "safe ls", "list safes", safeList)
+	register("safe", "create", "safe create <name>", "create a safe", safeCreate)
+	register("safe", "delete", "safe delete <safe-id>", "delete a safe", safeDelete)
+}
+
+// secretObj returns a SecretObj for the current session.
+func (cli *cliContext) secretObj(decrypt bool) (*secrets.SecretObj, error) {
+	authenticationObj, err := cli.session()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewSecretObj(*authenticationObj, cli.log, cli.config.MaxFileSecretSizeBytes, decrypt)
+}
+
+// printSecretValues prints a single value as is, so it can be captured by scripts, and several values as a table.
+func (cli *cliContext) printSecretValues(paths []string, values map[string]string) error {
+	if len(paths) == 1 && cli.config.Output == "table" {
+		_, err := fmt.Fprintln(cli.stdout, values[paths[0]])
+		return err
+	}
+	return cli.print(values)
+}
+
+func secretGet(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "secret get <path>...")
+	paths, err := parseCommandFlags(flags, args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	secretObj, err := cli.secretObj(true)
+	if err != nil {
+		return err
+	}
+
+	values, err := secretObj.GetSecrets(paths, cli.config.Separator)
+	if len(values) > 0 {
+		if printErr := cli.printSecretValues(paths, values); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
+
+func secretCreate(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "secret create --folder <folder> --title <title> [flags]")
+	folder := flags.String("folder", "", "folder the secret is created in")
+	title := flags.String("title", "", "secret title")
+	description := flags.String("description", "", "secret description")
+	secretType := flags.String("type", "credential", "credential, text or file")
+	username := flags.String("username", "", "username of a credential secret")
+	file := flags.String("file", "", "content of a file secret")
+	ownerUserId := flags.Int("owner-user-id", 0, "owner user ID")
+	ownerGroupId := flags.Int("owner-group-id", 0, "owner group ID (API version 3.1 and later)")
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	if *folder == "" || *title == "" {
+		return usageError{errors.New("--folder and --title are required")}
+	}
+
+	base := entities.SecretDetailsBaseConfig{Title: *title, Description: *description}
+	ownersByOwnerId := []entities.OwnerDetailsOwnerId{{OwnerId: *ownerUserId}}
+	ownersByGroupId := []entities.OwnerDetailsGroupId{{GroupId: *ownerGroupId, UserId: *ownerUserId}}
+
+	var secretDetails interface{}
+	switch *secretType {
+	case "credential", "text":
+		// the value is read from stdin so it does not end up in the shell history.
+		value, err := readStdin(cli)
+		if err != nil {
+			return err
+		}
+		if *secretType == "credential" {
+			secretDetails = entities.SecretCredentialInput{SecretDetailsBaseConfig: base, Username: *username, Password: value, OwnerId: *ownerUserId, OwnerType: "User", OwnersByOwnerId: ownersByOwnerId, OwnersByGroupId: ownersByGroupId}
+		} else {
+			secretDetails = entities.SecretTextInput{SecretDetailsBaseConfig: base, Text: value, OwnerId: *ownerUserId, OwnerType: "User", OwnersByOwnerId: ownersByOwnerId, OwnersByGroupId: ownersByGroupId}
+		}
+	case "file":
+		content, err := os.ReadFile(*file)
+		if err != nil {
+			return usageError{err}
+		}
+		secretDetails = entities.SecretFileInput{SecretDetailsBaseConfig: base, FileName: filepath.Base(*file), FileContent: string(content), OwnerId: *ownerUserId, OwnerType: "User", OwnersByOwnerId: ownersByOwnerId, OwnersByGroupId: ownersByGroupId}
+	default:
+		return usageError{fmt.Errorf("invalid secret type %q", *secretType)}
+	}
+
+	secretObj, err := cli.secretObj(false)
+	if err != nil {
+		return err
+	}
+
+	response, err := secretObj.CreateSecretFlow(*folder, secretDetails)
+	if err != nil {
+		return err
+	}
+	return cli.print(response, "Id", "Title", "FolderId")
+}
+
+// readStdin returns stdin without the trailing newline.
+func readStdin(cli *cliContext) (string, error) {
+	content, err := io.ReadAll(cli.stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+func secretDelete(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "secret delete <secret-id>")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	secretObj, err := cli.secretObj(false)
+	if err != nil {
+		return err
+	}
+	return secretObj.DeleteSecretById(positional[0])
+}
+
+func secretList(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "secret ls [folder]")
+	positional, err := parseCommandFlags(flags, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	folder := ""
+	if len(positional) == 1 {
+		folder = positional[0]
+	}
+
+	secretObj, err := cli.secretObj(false)
+	if err != nil {
+		return err
+	}
+
+	secretsList, err := secretObj.SecretGetSecretsListFlow(folder, cli.config.Separator)
+	if err != nil {
+		return err
+	}
+
+	// values are never listed, even if the API returned them.
+	for i := range secretsList {
+		secretsList[i].Password = ""
+	}
+	return cli.print(secretsList, "Id", "Title", "SecretType")
+}
+
+func folderList(cli *cliContext, args []string) error {
+	return listFolders(cli, args, "folder ls", (*secrets.SecretObj).SecretGetFoldersListFlow)
+}
+
+func safeList(cli *cliContext, args []string) error {
+	return listFolders(cli, args, "safe ls", (*secrets.SecretObj).SecretGetSafesListFlow)
+}
+
+// listFolders prints the folders or safes returned by list.
+func listFolders(cli *cliContext, args []string, usage string, list func(*secrets.SecretObj) ([]entities.FolderResponse, error)) error {
+	flags := newCommandFlags(cli, usage)
+	if _, err := parseCommandFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	secretObj, err := cli.secretObj(false)
+	if err != nil {
+		return err
+	}
+
+	folders, err := list(secretObj)
+	if err != nil {
+		return err
+	}
+	return cli.print(folders, "Id", "Name", "Description")
+}
+
+func folderCreate(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "folder create --parent <folder> <name>")
+	parent := flags.String("parent", "", "parent folder name")
+	description := flags.String("description", "", "folder description")
+	userGroupId := flags.Int("user-group-id", 0, "user group ID")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *parent == "" {
+		return usageError{errors.New("--parent is required")}
+	}
+
+	return createFolder(cli, *parent, entities.FolderDetails{Name: positional[0], Description: *description, UserGroupId: *userGroupId, FolderType: "FOLDER"})
+}
+
+func safeCreate(cli *cliContext, args []string) error {
+	flags := newCommandFlags(cli, "safe create <name>")
+	description := flags.String("description", "", "safe description")
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	return createFolder(cli, "", entities.FolderDetails{Name: positional[0], Description: *description, FolderType: "SAFE"})
+}
+
+// createFolder creates a folder or safe and prints it.
+func createFolder(cli *cliContext, parent string, folderDetails entities.FolderDetails) error {
+	secretObj, err := cli.secretObj(false)
+	if err != nil {
+		return err
+	}
+
+	response, err := secretObj.CreateFolderFlow(parent, folderDetails)
+	if err != nil {
+		return err
+	}
+	return cli.print(response, "Id", "Name", "Description")
+}
+
+func folderDelete(cli *cliContext, args []string) error {
+	return deleteFolder(cli, args, "folder delete <folder-id>", (*secrets.SecretObj).DeleteFolderById)
+}
+
+func safeDelete(cli *cliContext, args []string) error {
+	return deleteFolder(cli, args, "safe delete <safe-id>", (*secrets.SecretObj).DeleteSafeById)
+}
+
+// deleteFolder deletes the folder or safe given as the only argument.
+func deleteFolder(cli *cliContext, args []string, usage string, deleteById func(*secrets.SecretObj, string) error) error {
+	flags := newCommandFlags(cli, usage)
+	positional, err := parseCommandFlags(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	secretObj, err := cli.secretObj(false)
+	if err != nil {
+		return err
+	}
+	return deleteById(secretObj, positional[0])
+}
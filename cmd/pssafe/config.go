@@ -0,0 +1,122 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	psconfig "github.com/BeyondTrust/go-client-library-passwordsafe/api/config"
+)
+
+// config holds the connection settings and the output settings of the command
+// line. Values are read from the config file, then environment variables, then
+// command line flags, each overriding the previous one.
+type config struct {
+	psconfig.Config `yaml:",inline"`
+	Output          string `json:"output" yaml:"output"`
+	Verbose         bool   `json:"verbose" yaml:"verbose"`
+}
+
+// globalOptions are the values bound to the global flags.
+type globalOptions struct {
+	configPath string
+	values     config
+}
+
+// defaultConfig returns the settings used when nothing else is configured.
+func defaultConfig() config {
+	return config{Config: psconfig.Default(), Output: "table"}
+}
+
+// bindGlobalFlags registers the global flags. Secrets (client secret, API key,
+// certificate key) are only read from the config file or environment so they
+// never show up in the process list or shell history.
+func bindGlobalFlags(flags *flag.FlagSet) *globalOptions {
+	options := &globalOptions{}
+	defaults := defaultConfig()
+
+	flags.StringVar(&options.configPath, "config", "", "JSON or YAML config file (default $PSSAFE_CONFIG or <user config dir>/pssafe/config.json)")
+	flags.StringVar(&options.values.ApiUrl, "api-url", "", "Password Safe API URL ($PASSWORD_SAFE_API_URL)")
+	flags.StringVar(&options.values.ApiVersion, "api-version", defaults.ApiVersion, "Password Safe API version ($PASSWORD_SAFE_API_VERSION)")
+	flags.StringVar(&options.values.ClientId, "client-id", "", "API OAuth client ID ($PASSWORD_SAFE_CLIENT_ID)")
+	flags.BoolVar(&options.values.VerifyCa, "verify-ca", defaults.VerifyCa, "verify the server certificate authority ($PASSWORD_SAFE_VERIFY_CA)")
+	flags.IntVar(&options.values.ClientTimeOutInSeconds, "timeout", defaults.ClientTimeOutInSeconds, "request timeout in seconds ($PASSWORD_SAFE_CLIENT_TIMEOUT_SECONDS)")
+	flags.StringVar(&options.values.Separator, "separator", defaults.Separator, "path separator ($PASSWORD_SAFE_SEPARATOR)")
+	flags.StringVar(&options.values.Output, "output", defaults.Output, "output format: table or json ($PSSAFE_OUTPUT)")
+	flags.BoolVar(&options.values.Verbose, "verbose", false, "log requests to stderr")
+	return options
+}
+
+// loadConfig merges defaults, the config file, environment variables and the flags set on the command line.
+func loadConfig(options *globalOptions, flags *flag.FlagSet) (config, error) {
+	cfg := defaultConfig()
+
+	path, explicit := options.configPath, options.configPath != ""
+	if !explicit {
+		path, explicit = os.Getenv("PSSAFE_CONFIG"), os.Getenv("PSSAFE_CONFIG") != ""
+	}
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "pssafe", "config.json")
+		}
+	}
+
+	if path != "" {
+		_, err := os.Stat(path)
+		switch {
+		case err == nil:
+			if err = psconfig.DecodeFile(path, &cfg); err != nil {
+				return cfg, configError{err}
+			}
+		case explicit || !errors.Is(err, os.ErrNotExist):
+			return cfg, configError{err}
+		}
+	}
+
+	if err := applyEnv(&cfg); err != nil {
+		return cfg, err
+	}
+
+	var overrides []psconfig.Option
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "api-url":
+			overrides = append(overrides, psconfig.WithApiUrl(options.values.ApiUrl))
+		case "api-version":
+			overrides = append(overrides, psconfig.WithApiVersion(options.values.ApiVersion))
+		case "client-id":
+			overrides = append(overrides, psconfig.WithClientCredentials(options.values.ClientId, cfg.ClientSecret))
+		case "verify-ca":
+			overrides = append(overrides, psconfig.WithVerifyCa(options.values.VerifyCa))
+		case "timeout":
+			overrides = append(overrides, psconfig.WithClientTimeOut(options.values.ClientTimeOutInSeconds))
+		case "separator":
+			overrides = append(overrides, psconfig.WithSeparator(options.values.Separator))
+		case "output":
+			cfg.Output = options.values.Output
+		case "verbose":
+			cfg.Verbose = options.values.Verbose
+		}
+	})
+	cfg.Apply(overrides...)
+
+	if cfg.Output != "table" && cfg.Output != "json" {
+		return cfg, configError{fmt.Errorf("invalid output format %q, use table or json", cfg.Output)}
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides cfg with the environment variables that are set.
+func applyEnv(cfg *config) error {
+	if err := cfg.LoadEnv(); err != nil {
+		return configError{err}
+	}
+	if env, ok := os.LookupEnv("PSSAFE_OUTPUT"); ok {
+		cfg.Output = env
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+// Exit codes, scripts can rely on them.
+const (
+	ExitOK             = 0
+	ExitError          = 1
+	ExitUsage          = 2
+	ExitAuthentication = 3
+	ExitNotFound       = 4
+	ExitRejected       = 5
+	ExitUnavailable    = 6
+)
+
+// usageError reports invalid command line input.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// configError reports invalid or incomplete configuration.
+type configError struct {
+	err error
+}
+
+func (e configError) Error() string {
+	return e.err.Error()
+}
+
+func (e configError) Unwrap() error {
+	return e.err
+}
+
+// authenticationError reports a failed sign in.
+type authenticationError struct {
+	err error
+}
+
+func (e authenticationError) Error() string {
+	return "authentication failed: " + e.err.Error()
+}
+
+func (e authenticationError) Unwrap() error {
+	return e.err
+}
+
+// childExitError carries the exit code of a child process started by exec, pssafe exits with the same code.
+type childExitError struct {
+	code int
+}
+
+func (e childExitError) Error() string {
+	return "command exited with code " + strconv.Itoa(e.code)
+}
+
+// exitCode maps err to an exit code.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var usage usageError
+	var config configError
+	if errors.As(err, &usage) || errors.As(err, &config) {
+		return ExitUsage
+	}
+
+	var authentication authenticationError
+	if errors.As(err, &authentication) {
+		return ExitAuthentication
+	}
+
+	var notFound *utils.NotFoundError
+	if errors.As(err, &notFound) {
+		return ExitNotFound
+	}
+
+	var rateLimited *utils.RateLimitedError
+	var circuitOpen *utils.CircuitOpenError
+	if errors.As(err, &rateLimited) || errors.As(err, &circuitOpen) {
+		return ExitUnavailable
+	}
+
+	switch statusCode := utils.StatusCode(err); {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ExitAuthentication
+	case statusCode == http.StatusNotFound:
+		return ExitNotFound
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError:
+		return ExitUnavailable
+	case statusCode >= http.StatusBadRequest:
+		return ExitRejected
+	}
+
+	var networkError net.Error
+	if errors.As(err, &networkError) {
+		return ExitUnavailable
+	}
+	return ExitError
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// command is a subcommand such as "secret get".
+type command struct {
+	usage       string
+	description string
+	run         func(cli *cliContext, args []string) error
+}
+
+// commands maps "<group> <verb>", or "<group>" for commands without subcommands, to its command.
+var commands = map[string]command{}
+
+// register adds a command, called from the init functions of the command files. An
+// empty verb registers a command without subcommands such as "exec".
+func register(group string, verb string, usage string, description string, run func(cli *cliContext, args []string) error) {
+	commands[strings.TrimSpace(group+" "+verb)] = command{usage: usage, description: description, run: run}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("pssafe", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { printUsage(stderr, flags) }
+
+	options := bindGlobalFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		printUsage(stderr, flags)
+		return ExitUsage
+	}
+
+	group := args[0]
+	if group == "help" {
+		printUsage(stdout, flags)
+		return ExitOK
+	}
+	if group == "version" {
+		fmt.Fprintln(stdout, version())
+		return ExitOK
+	}
+
+	cmd, ok := commands[group]
+	if ok {
+		args = args[1:]
+	} else {
+		if len(args) < 2 {
+			fmt.Fprintf(stderr, "missing %v subcommand\n", group)
+			printGroupUsage(stderr, group)
+			return ExitUsage
+		}
+
+		cmd, ok = commands[group+" "+args[1]]
+		if !ok {
+			fmt.Fprintf(stderr, "unknown command %q\n", group+" "+args[1])
+			printGroupUsage(stderr, group)
+			return ExitUsage
+		}
+		args = args[2:]
+	}
+
+	config, err := loadConfig(options, flags)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return exitCode(err)
+	}
+
+	cli := newCliContext(config, stdin, stdout, stderr)
+	defer cli.close()
+
+	if err = cmd.run(cli, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		var childExit childExitError
+		if errors.As(err, &childExit) {
+			return childExit.code
+		}
+		fmt.Fprintln(stderr, "error:", err)
+		return exitCode(err)
+	}
+	return ExitOK
+}
+
+// printUsage prints the global flags and every command.
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: pssafe [global flags] <command> <subcommand> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	printCommands(w, "")
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	flags.SetOutput(w)
+	flags.PrintDefaults()
+}
+
+// printGroupUsage prints the commands of group.
+func printGroupUsage(w io.Writer, group string) {
+	printCommands(w, group+" ")
+}
+
+// printCommands prints the usage of the commands whose name starts with prefix.
+func printCommands(w io.Writer, prefix string) {
+	var names []string
+	for name := range commands {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(table, "  %v\t%v\n", commands[name].usage, commands[name].description)
+	}
+	_ = table.Flush()
+}
+
+// newCommandFlags returns a flag set for a subcommand that reports errors as usage errors.
+func newCommandFlags(cli *cliContext, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(usage, flag.ContinueOnError)
+	flags.SetOutput(cli.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(cli.stderr, "Usage: pssafe", usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseCommandFlags parses args and checks the number of positional arguments.
+func parseCommandFlags(flags *flag.FlagSet, args []string, minArgs int, maxArgs int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, usageError{err}
+	}
+
+	positional := flags.Args()
+	if len(positional) < minArgs || (maxArgs >= 0 && len(positional) > maxArgs) {
+		flags.Usage()
+		return nil, usageError{fmt.Errorf("unexpected number of arguments: %v", len(positional))}
+	}
+	return positional, nil
+}
+
+// Version is set at build time with -ldflags "-X main.Version=<version>".
+var Version = "dev"
+
+// version returns the version line printed by "pssafe version".
+func version() string {
+	return "pssafe " + Version
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+// Unit tests for pssafe command.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/utils"
+)
+
+const apiPath = "/BeyondTrust/api/public/v3"
+
+// newFakePasswordSafe returns a TLS server answering the endpoints used by the tests,
+// extra handlers override the defaults.
+func newFakePasswordSafe(t *testing.T, extra map[string]http.HandlerFunc) *httptest.Server {
+	handlers := map[string]http.HandlerFunc{
+		"/Auth/connect/token": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"access_token": "fake_token", "expires_in": 600, "token_type": "Bearer", "scope": "publicapi"}`))
+		},
+		"/Auth/SignAppIn": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"UserId":1, "EmailAddress":"test@beyondtrust.com"}`))
+		},
+		"/Auth/Signout": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(``))
+		},
+		"/secrets-safe/secrets": func(w http.ResponseWriter, r *http.Request) {
+			title := r.URL.Query().Get("title")
+			_, _ = w.Write([]byte(`[{"SecretType": "CREDENTIAL", "Password": "password_of_` + title + `", "Id": "9152f5b6-07d6-4955-175a-08db047219ce", "Title": "` + title + `"}]`))
+		},
+		"/ManagedAccounts": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"SystemId":1,"AccountId":10}`))
+		},
+		"/Requests": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`124`))
+		},
+		"/Credentials/124": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`"fake_credential"`))
+		},
+	}
+	for path, handler := range extra {
+		handlers[path] = handler
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler, ok := handlers[strings.TrimPrefix(r.URL.Path, apiPath)]; ok {
+			handler(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runCli runs pssafe against server and returns the exit code, stdout and stderr.
+func runCli(t *testing.T, server *httptest.Server, args ...string) (int, string, string) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"retryMaxElapsedTimeMinutes": 0}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PSSAFE_CONFIG", configPath)
+	t.Setenv("PASSWORD_SAFE_API_URL", server.URL+apiPath)
+	t.Setenv("PASSWORD_SAFE_CLIENT_ID", "6138d050-e266-4b05-9ced-35e7dd5093ae")
+	t.Setenv("PASSWORD_SAFE_CLIENT_SECRET", "71eec5f0-a07c-4b10-b0d5-8c5d2f1e2ec3")
+	t.Setenv("PASSWORD_SAFE_VERIFY_CA", "false")
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(""), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSecretGet(t *testing.T) {
+	server := newFakePasswordSafe(t, nil)
+
+	code, stdout, stderr := runCli(t, server, "secret", "get", "folder1/title1")
+	if code != ExitOK || stdout != "password_of_title1\n" {
+		t.Errorf("Test case Failed %v %q %q", code, stdout, stderr)
+	}
+
+	code, stdout, _ = runCli(t, server, "-output", "json", "secret", "get", "folder1/title1", "folder1/title2")
+	var values map[string]string
+	if err := json.Unmarshal([]byte(stdout), &values); err != nil || code != ExitOK {
+		t.Fatalf("Test case Failed %v %v %q", code, err, stdout)
+	}
+	if values["folder1/title2"] != "password_of_title2" || len(values) != 2 {
+		t.Errorf("Test case Failed %v", values)
+	}
+}
+
+func TestAccountCheckout(t *testing.T) {
+	server := newFakePasswordSafe(t, nil)
+
+	code, stdout, stderr := runCli(t, server, "account", "checkout", "system01/account01")
+	if code != ExitOK {
+		t.Fatalf("Test case Failed %v %q", code, stderr)
+	}
+	for _, expected := range []string{"RequestId    124", "Password     fake_credential"} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("output does not contain %q: %q", expected, stdout)
+		}
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	notFound := newFakePasswordSafe(t, map[string]http.HandlerFunc{
+		"/ManagedAccounts": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`"Managed account not found"`))
+		},
+	})
+	unauthorized := newFakePasswordSafe(t, map[string]http.HandlerFunc{
+		"/Auth/connect/token": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		},
+	})
+	missing := newFakePasswordSafe(t, map[string]http.HandlerFunc{
+		"/secrets-safe/secrets": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[]`))
+		},
+		"/ManagedAccounts": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[]`))
+		},
+	})
+	rejected := newFakePasswordSafe(t, map[string]http.HandlerFunc{
+		"/ManagedAccounts/10/Credentials/Change": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`"Password changes are not allowed"`))
+		},
+	})
+
+	testCases := []struct {
+		name     string
+		server   *httptest.Server
+		args     []string
+		exitCode int
+	}{
+		{"not found", notFound, []string{"account", "checkout", "system01/account01"}, ExitNotFound},
+		{"unauthorized", unauthorized, []string{"secret", "get", "folder1/title1"}, ExitAuthentication},
+		{"missing secret", missing, []string{"secret", "get", "folder1/title1"}, ExitNotFound},
+		{"missing account", missing, []string{"account", "get", "upn:svc_app@corp.example.com"}, ExitNotFound},
+		{"rejected", rejected, []string{"account", "rotate", "system01/account01"}, ExitRejected},
+		{"unknown command", notFound, []string{"secret", "move"}, ExitUsage},
+		{"missing argument", notFound, []string{"secret", "get"}, ExitUsage},
+		{"invalid output", notFound, []string{"-output", "yaml", "platform", "ls"}, ExitUsage},
+		{"help", notFound, []string{"help"}, ExitOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			code, stdout, stderr := runCli(t, testCase.server, testCase.args...)
+			if code != testCase.exitCode {
+				t.Errorf("exit code %v, expected %v: %q %q", code, testCase.exitCode, stdout, stderr)
+			}
+		})
+	}
+	if code := exitCode(&utils.RateLimitedError{RetryAfter: time.Second}); code != ExitUnavailable {
+		t.Errorf("exit code %v of a client rate limit, expected %v", code, ExitUnavailable)
+	}
+	if code := exitCode(&utils.CircuitOpenError{RetryAfter: time.Second}); code != ExitUnavailable {
+		t.Errorf("exit code %v of an open circuit, expected %v", code, ExitUnavailable)
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"apiUrl": "https://file/BeyondTrust/api/public/v3", "clientId": "file-client", "separator": "-", "output": "json"}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PASSWORD_SAFE_CLIENT_ID", "env-client")
+	t.Setenv("PASSWORD_SAFE_SEPARATOR", "|")
+
+	flags := flag.NewFlagSet("pssafe", flag.ContinueOnError)
+	options := bindGlobalFlags(flags)
+	if err := flags.Parse([]string{"-config", path, "-separator", "+"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(options, flags)
+	if err != nil {
+		t.Fatalf("Test case Failed: %v", err)
+	}
+
+	if cfg.ApiUrl != "https://file/BeyondTrust/api/public/v3" || cfg.ClientId != "env-client" || cfg.Separator != "+" || cfg.Output != "json" || !cfg.VerifyCa {
+		t.Errorf("Test case Failed %+v", cfg)
+	}
+
+	options.configPath = filepath.Join(t.TempDir(), "missing.json")
+	if _, err = loadConfig(options, flags); exitCode(err) != ExitUsage {
+		t.Errorf("a missing explicit config file must be a usage error: %v", err)
+	}
+}
+
+// TestHelperProcess is the command started by the exec tests, it is skipped in normal runs.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("PSSAFE_HELPER_PROCESS") != "1" {
+		return
+	}
+	for _, name := range []string{"PASSWORD_SAFE_CLIENT_SECRET", "PASSWORD_SAFE_API_KEY"} {
+		if _, ok := os.LookupEnv(name); ok {
+			fmt.Fprintf(os.Stderr, "%v must not be passed to the command", name)
+			os.Exit(8)
+		}
+	}
+	fmt.Fprintf(os.Stdout, "%v %v", os.Getenv("DB_PASS"), os.Getenv("SSH_PASS"))
+	os.Exit(7)
+}
+
+func TestExec(t *testing.T) {
+	var checkIns atomic.Int32
+	server := newFakePasswordSafe(t, map[string]http.HandlerFunc{
+		"/Requests/124/checkin": func(w http.ResponseWriter, r *http.Request) {
+			checkIns.Add(1)
+			_, _ = w.Write([]byte(``))
+		},
+	})
+	t.Setenv("PSSAFE_HELPER_PROCESS", "1")
+	t.Setenv("PASSWORD_SAFE_API_KEY", strings.Repeat("a", 128)+";runas=user;")
+
+	code, stdout, stderr := runCli(t, server, "exec", "--secret", "DB_PASS=folder1/title1", "--account", "SSH_PASS=system01/account01",
+		"--", os.Args[0], "-test.run=^TestHelperProcess$")
+	if code != 7 || stdout != "password_of_title1 fake_credential" {
+		t.Errorf("Test case Failed %v %q %q", code, stdout, stderr)
+	}
+	if checkIns.Load() != 1 {
+		t.Errorf("expected one check in, got %v", checkIns.Load())
+	}
+	if os.Getenv("DB_PASS") != "" {
+		t.Error("secrets must only be set in the environment of the child process")
+	}
+
+	code, _, _ = runCli(t, server, "exec", "--secret", "PASS=folder1/title1", "--account", "PASS=system01/account01", "--", os.Args[0])
+	if code != ExitUsage {
+		t.Errorf("a variable mapped twice must be a usage error, got %v", code)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 BeyondTrust. All rights reserved.
+// Command pssafe is a command line client for Password Safe built on the go client library.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// print writes value as indented JSON or as a table of the given struct fields.
+// Slices print one row per element, a struct prints one row per field and
+// a map[string]string prints its sorted key/value pairs.
+func (cli *cliContext) print(value interface{}, columns ...string) error {
+	if cli.config.Output == "json" {
+		encoder := json.NewEncoder(cli.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+	return printTable(cli.stdout, value, columns)
+}
+
+// printTable writes value as a tab aligned table.
+func printTable(w io.Writer, value interface{}, columns []string) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	switch v := value.(type) {
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintln(table, "PATH\tVALUE")
+		for _, key := range keys {
+			fmt.Fprintf(table, "%v\t%v\n", key, v[key])
+		}
+		return table.Flush()
+	}
+
+	reflected := reflect.Indirect(reflect.ValueOf(value))
+	switch reflected.Kind() {
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintln(table, strings.ToUpper(strings.Join(columns, "\t")))
+		for i := 0; i < reflected.Len(); i++ {
+			fmt.Fprintln(table, strings.Join(fieldValues(reflected.Index(i), columns), "\t"))
+		}
+	case reflect.Struct:
+		for i, fieldValue := range fieldValues(reflected, columns) {
+			fmt.Fprintf(table, "%v\t%v\n", columns[i], fieldValue)
+		}
+	default:
+		fmt.Fprintln(table, value)
+	}
+	return table.Flush()
+}
+
+// fieldValues returns the text of the named fields of a struct value.
+func fieldValues(value reflect.Value, columns []string) []string {
+	value = reflect.Indirect(value)
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		field := value.FieldByName(column)
+		if field.IsValid() {
+			values[i] = fmt.Sprintf("%v", field.Interface())
+		}
+	}
+	return values
+}
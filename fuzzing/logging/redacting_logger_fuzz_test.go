@@ -0,0 +1,80 @@
+package fuzzing_logging
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/BeyondTrust/go-client-library-passwordsafe/api/logging"
+)
+
+// tokenCharacters are the characters a bearer token pattern is expected to mask.
+const tokenCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._~+/"
+
+// recordingLogger keeps every message and field value it receives.
+type recordingLogger struct {
+	entries []string
+}
+
+func (r *recordingLogger) Info(msg string)  { r.entries = append(r.entries, msg) }
+func (r *recordingLogger) Error(msg string) { r.entries = append(r.entries, msg) }
+func (r *recordingLogger) Debug(msg string) { r.entries = append(r.entries, msg) }
+func (r *recordingLogger) Warn(msg string)  { r.entries = append(r.entries, msg) }
+
+func (r *recordingLogger) DebugFields(msg string, fields ...logging.Field) {
+	r.record(msg, nil, fields)
+}
+func (r *recordingLogger) InfoFields(msg string, fields ...logging.Field) {
+	r.record(msg, nil, fields)
+}
+func (r *recordingLogger) WarnFields(msg string, fields ...logging.Field) {
+	r.record(msg, nil, fields)
+}
+func (r *recordingLogger) ErrorFields(msg string, err error, fields ...logging.Field) {
+	r.record(msg, err, fields)
+}
+
+func (r *recordingLogger) record(msg string, err error, fields []logging.Field) {
+	r.entries = append(r.entries, msg)
+	if err != nil {
+		r.entries = append(r.entries, err.Error())
+	}
+	for _, field := range fields {
+		r.entries = append(r.entries, fmt.Sprintf("%v", field.Value))
+	}
+}
+
+func FuzzRedactingLogger(f *testing.F) {
+
+	f.Add("credential_in_sub_3_password", "Retrieving secret ", " from folder1", "eyJhbGciOiJIUzI1NiJ9")
+	f.Add("a*", "aa", "*", "token")
+	f.Add("p@ss word", "pwd=[", "]", "abc.def")
+
+	f.Fuzz(func(t *testing.T, secret string, prefix string, suffix string, token string) {
+
+		recorder := &recordingLogger{}
+		redactingLogger := logging.NewRedactingLogger(recorder)
+		logging.RegisterSecret(redactingLogger, secret)
+
+		redactingLogger.Info(prefix + secret + suffix)
+		redactingLogger.ErrorFields(prefix, fmt.Errorf("%s%s", secret, suffix), logging.String("value", suffix+secret))
+
+		// values made only of mask characters can not be told apart from the mask.
+		if strings.Trim(secret, "*") != "" {
+			for _, entry := range recorder.entries {
+				if strings.Contains(entry, secret) {
+					t.Errorf("registered secret %q leaked: %q", secret, entry)
+				}
+			}
+		}
+
+		if token == "" || strings.Trim(token, tokenCharacters) != "" {
+			return
+		}
+
+		expected := "Authorization: Bearer " + logging.RedactionMask
+		if redacted := logging.NewRedactingLogger(recorder).Redact("Authorization: Bearer " + token); redacted != expected {
+			t.Errorf("bearer token %q leaked: %q", token, redacted)
+		}
+	})
+}